@@ -0,0 +1,127 @@
+package shopify
+
+import (
+	"encoding/json"
+	"github.com/google/go-querystring/query"
+	"github.com/pkg/errors"
+	"strconv"
+)
+
+/*
+A one time charge for the app. The merchant approves it at ConfirmationUrl and is sent back to
+ReturnUrl with the charge_id, test charges go through the same flow without billing anyone.
+*/
+type ApplicationCharge struct {
+	ConfirmationUrl string `json:"confirmation_url,omitempty"`
+	CreatedAt       string `json:"created_at,omitempty"`
+	Id              int    `json:"id,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Price           string `json:"price,omitempty"`
+	ReturnUrl       string `json:"return_url,omitempty"`
+	Status          string `json:"status,omitempty"`
+	Test            bool   `json:"test,omitempty"`
+	UpdatedAt       string `json:"updated_at,omitempty"`
+}
+
+type ApplicationChargeOptions struct {
+	SinceId int    `url:"since_id,omitempty"`
+	Fields  string `url:"fields,omitempty"`
+}
+
+func (a ApplicationChargeOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(a)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", a)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
+}
+
+type ApplicationChargeWrapper struct {
+	ApplicationCharge *ApplicationCharge `json:"application_charge"`
+}
+
+func (a ApplicationChargeWrapper) GetResourceName() string {
+	return "application_charges"
+}
+
+func (a ApplicationChargeWrapper) GetId() int {
+	return a.ApplicationCharge.Id
+}
+
+func (a ApplicationChargeWrapper) BuildGetUrl(request Request) string {
+	return BuildIdUrl(request, a.GetResourceName(), a.GetId())
+}
+
+func (a ApplicationChargeWrapper) BuildCreateUrl(request Request) string {
+	return BuildSimpleUrl(request, a.GetResourceName())
+}
+
+type ApplicationCharges struct {
+	ApplicationCharges []ApplicationCharge `json:"application_charges"`
+}
+
+type ApplicationChargesWrapper struct {
+	ApplicationCharges []ApplicationCharge
+}
+
+func (a *ApplicationChargesWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper ApplicationCharges
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return
+	}
+
+	a.ApplicationCharges = append(a.ApplicationCharges, wrapper.ApplicationCharges...)
+	return
+}
+
+func (a ApplicationChargesWrapper) GetResourceName() string {
+	return "application_charges"
+}
+
+/*
+Creates a one time charge that bills the merchant once and never renews. The merchant approves it
+at the ConfirmationUrl of the result. With Test set the approval flow is the same but nobody is billed.
+*/
+func (r *RestAdminClient) ApplicationChargeCreate(context Ctx, request ApplicationCharge) (result *ApplicationCharge, err error) {
+	if request.ReturnUrl == "" {
+		err = errors.New("an application charge needs a return url to send the merchant back to")
+		return
+	}
+
+	var returnWrapper = new(ApplicationChargeWrapper)
+	err = r.Create(context, returnWrapper, ApplicationChargeWrapper{ApplicationCharge: &request})
+	result = returnWrapper.ApplicationCharge
+
+	return
+}
+
+func (r *RestAdminClient) ApplicationChargeGet(context Ctx, id int) (result *ApplicationCharge, err error) {
+	wrapper := &ApplicationChargeWrapper{ApplicationCharge: &ApplicationCharge{Id: id}}
+	err = r.Get(context, wrapper)
+	result = wrapper.ApplicationCharge
+
+	return
+}
+
+func (r *RestAdminClient) ApplicationChargeList(context Ctx, options ApplicationChargeOptions) (results []ApplicationCharge, err error) {
+	var wrapper = &ApplicationChargesWrapper{}
+	_, err = r.List(context, options, wrapper)
+	results = wrapper.ApplicationCharges
+	return
+}
+
+/*
+Bills the merchant for a one time charge they accepted at its confirmation url, the charge must have
+the accepted status. From api version 2021-01 accepted one time charges are billed without this call.
+*/
+func (r *RestAdminClient) ApplicationChargeActivate(context Ctx, request ApplicationCharge) (result *ApplicationCharge, err error) {
+	var returnWrapper ApplicationChargeWrapper
+	path := "application_charges/" + strconv.Itoa(request.Id) + "/activate"
+	err = r.Send(context, "POST", path, nil, ApplicationChargeWrapper{ApplicationCharge: &request}, &returnWrapper)
+	result = returnWrapper.ApplicationCharge
+
+	return
+}
@@ -0,0 +1,48 @@
+package shopify
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestApplicationChargeCreateAndActivate(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/application_charges.json":
+			var wrapper ApplicationChargeWrapper
+			_ = json.NewDecoder(req.Body).Decode(&wrapper)
+			if !wrapper.ApplicationCharge.Test || wrapper.ApplicationCharge.ReturnUrl != "https://example.com/charged" {
+				rw.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			rw.WriteHeader(http.StatusCreated)
+			_, _ = rw.Write([]byte(`{"application_charge":{"id":1017262355,"name":"Super Duper Expensive action","price":"100.00","status":"pending","test":true,
+				"confirmation_url":"https://test.myshopify.com/admin/charges/1017262355/confirm_application_charge?signature=abc"}}`))
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/application_charges/1017262355/activate.json":
+			_, _ = rw.Write([]byte(`{"application_charge":{"id":1017262355,"status":"active","test":true}}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	if _, err := client.ApplicationChargeCreate(requestContext, ApplicationCharge{Name: "Super Duper Expensive action", Price: "100.00"}); err == nil {
+		t.Error("expected an error for a charge without a return url")
+	}
+
+	charge, err := client.ApplicationChargeCreate(requestContext, ApplicationCharge{
+		Name:      "Super Duper Expensive action",
+		Price:     "100.00",
+		ReturnUrl: "https://example.com/charged",
+		Test:      true,
+	})
+	if err != nil || charge.ConfirmationUrl == "" || charge.Status != "pending" {
+		t.Fatalf("unexpected charge %+v %v", charge, err)
+	}
+
+	charge, err = client.ApplicationChargeActivate(requestContext, *charge)
+	if err != nil || charge.Status != "active" {
+		t.Errorf("unexpected activated charge %+v %v", charge, err)
+	}
+}
@@ -0,0 +1,97 @@
+package shopify
+
+import (
+	"encoding/json"
+	"github.com/google/go-querystring/query"
+	"github.com/pkg/errors"
+)
+
+/*
+A credit the merchant can put towards future app charges, a test credit isn't applied.
+*/
+type ApplicationCredit struct {
+	Amount      string `json:"amount,omitempty"`
+	Description string `json:"description,omitempty"`
+	Id          int    `json:"id,omitempty"`
+	Test        bool   `json:"test,omitempty"`
+}
+
+type ApplicationCreditOptions struct {
+	Fields string `url:"fields,omitempty"`
+}
+
+func (a ApplicationCreditOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(a)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", a)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
+}
+
+type ApplicationCreditWrapper struct {
+	ApplicationCredit *ApplicationCredit `json:"application_credit"`
+}
+
+func (a ApplicationCreditWrapper) GetResourceName() string {
+	return "application_credits"
+}
+
+func (a ApplicationCreditWrapper) GetId() int {
+	return a.ApplicationCredit.Id
+}
+
+func (a ApplicationCreditWrapper) BuildGetUrl(request Request) string {
+	return BuildIdUrl(request, a.GetResourceName(), a.GetId())
+}
+
+func (a ApplicationCreditWrapper) BuildCreateUrl(request Request) string {
+	return BuildSimpleUrl(request, a.GetResourceName())
+}
+
+type ApplicationCredits struct {
+	ApplicationCredits []ApplicationCredit `json:"application_credits"`
+}
+
+type ApplicationCreditsWrapper struct {
+	ApplicationCredits []ApplicationCredit
+}
+
+func (a *ApplicationCreditsWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper ApplicationCredits
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return
+	}
+
+	a.ApplicationCredits = append(a.ApplicationCredits, wrapper.ApplicationCredits...)
+	return
+}
+
+func (a ApplicationCreditsWrapper) GetResourceName() string {
+	return "application_credits"
+}
+
+func (r *RestAdminClient) ApplicationCreditCreate(context Ctx, request ApplicationCredit) (result *ApplicationCredit, err error) {
+	var returnWrapper = new(ApplicationCreditWrapper)
+	err = r.Create(context, returnWrapper, ApplicationCreditWrapper{ApplicationCredit: &request})
+	result = returnWrapper.ApplicationCredit
+
+	return
+}
+
+func (r *RestAdminClient) ApplicationCreditGet(context Ctx, id int) (result *ApplicationCredit, err error) {
+	wrapper := &ApplicationCreditWrapper{ApplicationCredit: &ApplicationCredit{Id: id}}
+	err = r.Get(context, wrapper)
+	result = wrapper.ApplicationCredit
+
+	return
+}
+
+func (r *RestAdminClient) ApplicationCreditList(context Ctx, options ApplicationCreditOptions) (results []ApplicationCredit, err error) {
+	var wrapper = &ApplicationCreditsWrapper{}
+	_, err = r.List(context, options, wrapper)
+	results = wrapper.ApplicationCredits
+	return
+}
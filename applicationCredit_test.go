@@ -0,0 +1,38 @@
+package shopify
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestApplicationCreditCreateAndList(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/application_credits.json":
+			var wrapper ApplicationCreditWrapper
+			_ = json.NewDecoder(req.Body).Decode(&wrapper)
+			if wrapper.ApplicationCredit.Amount != "5.00" || !wrapper.ApplicationCredit.Test {
+				rw.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			rw.WriteHeader(http.StatusCreated)
+			_, _ = rw.Write([]byte(`{"application_credit":{"id":140583599,"amount":"5.00","description":"credit for application refund","test":true}}`))
+		case req.Method == "GET" && req.URL.Path == "/admin/api/2020-10/application_credits.json":
+			_, _ = rw.Write([]byte(`{"application_credits":[{"id":140583599,"amount":"5.00","description":"credit for application refund","test":true}]}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	credit, err := client.ApplicationCreditCreate(requestContext, ApplicationCredit{Amount: "5.00", Description: "credit for application refund", Test: true})
+	if err != nil || credit.Id != 140583599 {
+		t.Fatalf("unexpected credit %+v %v", credit, err)
+	}
+
+	credits, err := client.ApplicationCreditList(requestContext, ApplicationCreditOptions{})
+	if err != nil || len(credits) != 1 || credits[0].Amount != "5.00" {
+		t.Errorf("unexpected credits %+v %v", credits, err)
+	}
+}
@@ -0,0 +1,444 @@
+package shopify
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	BulkOperationCreated   = "CREATED"
+	BulkOperationRunning   = "RUNNING"
+	BulkOperationCompleted = "COMPLETED"
+	BulkOperationCanceling = "CANCELING"
+	BulkOperationCanceled  = "CANCELED"
+	BulkOperationFailed    = "FAILED"
+	BulkOperationExpired   = "EXPIRED"
+
+	defaultBulkPollInterval = time.Second
+	maxBulkPollInterval     = 30 * time.Second
+	maxBulkLineBytes        = 64 << 20
+	bulkParentIdKey         = "__parentId"
+	shopifyGidPrefix        = "gid://shopify/"
+)
+
+const bulkOperationRunMutation = `mutation bulkOperationRunQuery($query: String!) {
+	bulkOperationRunQuery(query: $query) {
+		bulkOperation { id status errorCode createdAt completedAt objectCount fileSize url partialDataUrl query }
+		userErrors { field message }
+	}
+}`
+
+const currentBulkOperationQuery = `{
+	currentBulkOperation { id status errorCode createdAt completedAt objectCount fileSize url partialDataUrl query }
+}`
+
+/*
+The child keys that differ from the snake cased plural of the child's type, so the children of a
+product end up in the variants and images fields of Product.
+*/
+var bulkChildKeys = map[string]string{
+	"ProductVariant": "variants",
+	"ProductImage":   "images",
+	"Image":          "images",
+}
+
+/*
+A bulk query run by Shopify in the background. ObjectCount and FileSize are unsigned 64 bit
+integers that GraphQL sends as strings. Url is empty when the query matched no objects.
+*/
+type BulkOperation struct {
+	Id             string `json:"id"`
+	Status         string `json:"status"`
+	ErrorCode      string `json:"errorCode"`
+	CreatedAt      string `json:"createdAt"`
+	CompletedAt    string `json:"completedAt"`
+	ObjectCount    string `json:"objectCount"`
+	FileSize       string `json:"fileSize"`
+	Url            string `json:"url"`
+	PartialDataUrl string `json:"partialDataUrl"`
+	Query          string `json:"query"`
+}
+
+/*
+Reports whether Shopify is done with the operation, successfully or not.
+*/
+func (b BulkOperation) Finished() bool {
+	switch b.Status {
+	case BulkOperationCompleted, BulkOperationCanceled, BulkOperationFailed, BulkOperationExpired:
+		return true
+	}
+
+	return false
+}
+
+/*
+Submits the query as a bulk operation, only one bulk query can run for a shop at a time.
+*/
+func (g *GraphQLClient) BulkOperationRunQuery(context Ctx, query string) (result *BulkOperation, err error) {
+	var response struct {
+		BulkOperationRunQuery struct {
+			BulkOperation *BulkOperation `json:"bulkOperation"`
+		} `json:"bulkOperationRunQuery"`
+	}
+
+	err = g.Query(context, bulkOperationRunMutation, map[string]interface{}{"query": query}, &response)
+	if err != nil {
+		err = errors.WithMessage(err, "unable to start the bulk operation")
+		return
+	}
+
+	result = response.BulkOperationRunQuery.BulkOperation
+	return
+}
+
+/*
+The shop's most recent bulk operation, nil when it has never run one.
+*/
+func (g *GraphQLClient) CurrentBulkOperation(context Ctx) (result *BulkOperation, err error) {
+	var response struct {
+		CurrentBulkOperation *BulkOperation `json:"currentBulkOperation"`
+	}
+
+	err = g.Query(context, currentBulkOperationQuery, nil, &response)
+	result = response.CurrentBulkOperation
+	return
+}
+
+/*
+Polls the current bulk operation until it finishes, waiting BulkPollInterval between the first
+polls and doubling the wait up to 30s. An error is returned when the operation didn't complete.
+*/
+func (g *GraphQLClient) BulkOperationWait(context Ctx) (result *BulkOperation, err error) {
+	wait := g.BulkPollInterval
+	if wait <= 0 {
+		wait = defaultBulkPollInterval
+	}
+
+	for {
+		result, err = g.CurrentBulkOperation(context)
+		if err != nil {
+			err = errors.WithMessage(err, "unable to poll the bulk operation")
+			return
+		}
+		if result == nil {
+			err = errors.New("the shop has no bulk operation to wait for")
+			return
+		}
+
+		if result.Finished() {
+			break
+		}
+
+		if err = sleepContext(context.Ctx, wait); err != nil {
+			err = errors.WithMessagef(err, "cancelled while waiting for bulk operation %v", result.Id)
+			return
+		}
+
+		wait *= 2
+		if wait > maxBulkPollInterval {
+			wait = maxBulkPollInterval
+		}
+	}
+
+	if result.Status != BulkOperationCompleted {
+		err = errors.Errorf("bulk operation %v finished as %v %v", result.Id, result.Status, result.ErrorCode)
+	}
+	return
+}
+
+/*
+Downloads the JSONL result of a completed operation. The url is signed, so it is requested without
+the access token. An empty url, for an operation that matched nothing, gives an empty reader.
+The reader must be closed.
+*/
+func (g *GraphQLClient) BulkOperationResult(context Ctx, url string) (reader *BulkResultReader, err error) {
+	if url == "" {
+		return NewBulkResultReader(ioutil.NopCloser(strings.NewReader(""))), nil
+	}
+
+	req, err := http.NewRequestWithContext(context.Ctx, "GET", url, nil)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to create the request for the bulk operation result %v", url)
+		return
+	}
+
+	resp, err := g.Rest.Http.Do(req)
+	if err != nil {
+		err = errors.WithMessage(err, "unable to download the bulk operation result")
+		return
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		err = errors.Errorf("received %d downloading the bulk operation result", resp.StatusCode)
+		return
+	}
+
+	reader = NewBulkResultReader(resp.Body)
+	return
+}
+
+/*
+Runs the bulk query, waits for it to complete and returns a reader over the result.
+
+	reader, err := graphQL.BulkQuery(ctx, `{ products { edges { node { id title variants { edges { node { id price } } } } } } }`)
+	...
+	defer reader.Close()
+	for reader.Next() {
+		var product Product
+		if err := reader.Decode(&product); err != nil {
+			...
+		}
+	}
+	if reader.Err() != nil {
+		...
+	}
+*/
+func (g *GraphQLClient) BulkQuery(context Ctx, query string) (reader *BulkResultReader, err error) {
+	if _, err = g.BulkOperationRunQuery(context, query); err != nil {
+		return
+	}
+
+	operation, err := g.BulkOperationWait(context)
+	if err != nil {
+		return
+	}
+
+	return g.BulkOperationResult(context, operation.Url)
+}
+
+/*
+BulkResultReader streams a bulk operation result one top level object at a time. Shopify writes
+nested connections as separate lines pointing at their parent with __parentId, always after the
+parent and before the next top level object, so the reader holds a single object with its
+children at a time.
+
+Objects are converted to match the REST types: keys are snake cased, ids and *_id fields holding
+gids become integers, tags lists are joined with ", " and children are collected under the snake
+cased plural of their type, variants for ProductVariant and images for ProductImage.
+*/
+type BulkResultReader struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+
+	current map[string]interface{}
+	index   map[string]map[string]interface{}
+	object  map[string]interface{}
+	err     error
+}
+
+func NewBulkResultReader(body io.ReadCloser) *BulkResultReader {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), maxBulkLineBytes)
+
+	return &BulkResultReader{
+		body:    body,
+		scanner: scanner,
+	}
+}
+
+/*
+Advances to the next top level object, returning false at the end of the result or on an error.
+*/
+func (b *BulkResultReader) Next() bool {
+	if b.err != nil {
+		return false
+	}
+
+	for b.scanner.Scan() {
+		line := bytes.TrimSpace(b.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var raw map[string]interface{}
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.UseNumber()
+		if err := decoder.Decode(&raw); err != nil {
+			b.err = errors.WithMessage(err, "unable to decode a bulk operation result line")
+			return false
+		}
+
+		gid, _ := raw["id"].(string)
+		parentId, isChild := raw[bulkParentIdKey].(string)
+		delete(raw, bulkParentIdKey)
+		object := convertBulkObject(raw)
+
+		if isChild {
+			parent, ok := b.index[parentId]
+			if !ok {
+				b.err = errors.Errorf("the bulk operation result has %v before its parent %v", gid, parentId)
+				return false
+			}
+
+			key := bulkChildKey(gid)
+			children, _ := parent[key].([]interface{})
+			parent[key] = append(children, object)
+			if gid != "" {
+				b.index[gid] = object
+			}
+			continue
+		}
+
+		previous := b.current
+		b.current = object
+		b.index = map[string]map[string]interface{}{}
+		if gid != "" {
+			b.index[gid] = object
+		}
+
+		if previous != nil {
+			b.object = previous
+			return true
+		}
+	}
+
+	if err := b.scanner.Err(); err != nil {
+		b.err = errors.WithMessage(err, "unable to read the bulk operation result")
+		return false
+	}
+
+	if b.current != nil {
+		b.object = b.current
+		b.current = nil
+		return true
+	}
+
+	b.object = nil
+	return false
+}
+
+/*
+The current object with its children, keyed as described on BulkResultReader.
+*/
+func (b *BulkResultReader) Object() map[string]interface{} {
+	return b.object
+}
+
+/*
+Decodes the current object into target, for example a *Product.
+*/
+func (b *BulkResultReader) Decode(target interface{}) (err error) {
+	if b.object == nil {
+		return errors.New("there is no current bulk operation object, call Next first")
+	}
+
+	buf, err := json.Marshal(b.object)
+	if err != nil {
+		err = errors.WithMessage(err, "unable to encode the bulk operation object")
+		return
+	}
+
+	err = json.Unmarshal(buf, target)
+	if err != nil {
+		err = errors.WithMessage(err, "unable to decode the bulk operation object")
+	}
+	return
+}
+
+func (b *BulkResultReader) Err() error {
+	return b.err
+}
+
+func (b *BulkResultReader) Close() error {
+	return b.body.Close()
+}
+
+func convertBulkObject(raw map[string]interface{}) map[string]interface{} {
+	object := make(map[string]interface{}, len(raw))
+	for key, value := range raw {
+		key = snakeCase(key)
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			value = convertBulkObject(v)
+		case string:
+			if key == "id" || strings.HasSuffix(key, "_id") {
+				if id, ok := parseGid(v); ok {
+					value = id
+				}
+			}
+		case []interface{}:
+			if key == "tags" {
+				value = joinTags(v)
+			}
+		}
+
+		object[key] = value
+	}
+
+	return object
+}
+
+func bulkChildKey(gid string) string {
+	resource := gidResource(gid)
+	if key, ok := bulkChildKeys[resource]; ok {
+		return key
+	}
+
+	return snakeCase(resource) + "s"
+}
+
+/*
+The resource type of a gid, ProductVariant for gid://shopify/ProductVariant/1.
+*/
+func gidResource(gid string) string {
+	parts := strings.Split(strings.TrimPrefix(gid, shopifyGidPrefix), "/")
+	return parts[0]
+}
+
+/*
+Parses the numeric id of a gid such as gid://shopify/Product/632910392, query parameters are ignored.
+*/
+func parseGid(gid string) (id int, ok bool) {
+	if !strings.HasPrefix(gid, shopifyGidPrefix) {
+		return
+	}
+
+	gid = strings.SplitN(gid, "?", 2)[0]
+	id, err := strconv.Atoi(gid[strings.LastIndex(gid, "/")+1:])
+	return id, err == nil
+}
+
+func joinTags(tags []interface{}) string {
+	values := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if value, ok := tag.(string); ok {
+			values = append(values, value)
+		}
+	}
+
+	return strings.Join(values, ", ")
+}
+
+/*
+Converts a camel case GraphQL field name to the snake case used by REST, inventoryQuantity becomes
+inventory_quantity and SKU becomes sku.
+*/
+func snakeCase(name string) string {
+	runes := []rune(name)
+	builder := strings.Builder{}
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			previousLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])
+			if previousLower || nextLower {
+				builder.WriteByte('_')
+			}
+			builder.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
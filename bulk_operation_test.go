@@ -0,0 +1,107 @@
+package shopify
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const bulkResult = `{"id":"gid://shopify/Product/632910392","title":"IPod Nano - 8GB","productType":"Cult Products","tags":["Emotive","Flash Memory"]}
+{"id":"gid://shopify/ProductVariant/808950810","price":"199.00","inventoryQuantity":10,"__parentId":"gid://shopify/Product/632910392"}
+{"id":"gid://shopify/ProductVariant/49148385","price":"199.00","inventoryItem":{"id":"gid://shopify/InventoryItem/49148385"},"__parentId":"gid://shopify/Product/632910392"}
+{"id":"gid://shopify/Product/921728736","title":"IPod Touch 8GB","tags":[]}
+`
+
+func TestBulkQuery(t *testing.T) {
+	polls := 0
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/results.jsonl" {
+			if req.Header.Get("X-Shopify-Access-Token") != "" {
+				rw.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = rw.Write([]byte(bulkResult))
+			return
+		}
+
+		var request graphQLRequest
+		_ = json.NewDecoder(req.Body).Decode(&request)
+		switch {
+		case strings.HasPrefix(request.Query, "mutation bulkOperationRunQuery"):
+			_, _ = rw.Write([]byte(`{"data":{"bulkOperationRunQuery":{"bulkOperation":{"id":"gid://shopify/BulkOperation/720918","status":"CREATED"},"userErrors":[]}}}`))
+		case strings.Contains(request.Query, "currentBulkOperation"):
+			polls++
+			if polls < 2 {
+				_, _ = rw.Write([]byte(`{"data":{"currentBulkOperation":{"id":"gid://shopify/BulkOperation/720918","status":"RUNNING"}}}`))
+				return
+			}
+			_, _ = rw.Write([]byte(`{"data":{"currentBulkOperation":{"id":"gid://shopify/BulkOperation/720918","status":"COMPLETED","objectCount":"4",
+				"url":"https://` + req.Host + `/results.jsonl"}}}`))
+		default:
+			rw.WriteHeader(http.StatusBadRequest)
+		}
+	})
+	defer server.Close()
+
+	graphQL := NewGraphQLClient(&client)
+	graphQL.BulkPollInterval = time.Millisecond
+
+	reader, err := graphQL.BulkQuery(requestContext, "{ products { edges { node { id title } } } }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	var products []Product
+	for reader.Next() {
+		var product Product
+		if err = reader.Decode(&product); err != nil {
+			t.Fatal(err)
+		}
+		products = append(products, product)
+	}
+	if reader.Err() != nil {
+		t.Fatal(reader.Err())
+	}
+
+	if polls != 2 || len(products) != 2 {
+		t.Fatalf("unexpected products %+v after %v polls", products, polls)
+	}
+
+	first := products[0]
+	if first.Id != 632910392 || first.ProductType != "Cult Products" || first.Tags != "Emotive, Flash Memory" || len(first.Variants) != 2 {
+		t.Errorf("unexpected product %+v", first)
+	}
+	if first.Variants[0].Id != 808950810 || first.Variants[0].InventoryQuantity != 10 || first.Variants[0].Price != "199.00" {
+		t.Errorf("unexpected variant %+v", first.Variants[0])
+	}
+	if products[1].Id != 921728736 || len(products[1].Variants) != 0 {
+		t.Errorf("unexpected product %+v", products[1])
+	}
+}
+
+func TestBulkResultReaderOrphanedChild(t *testing.T) {
+	body := `{"id":"gid://shopify/ProductVariant/808950810","__parentId":"gid://shopify/Product/632910392"}`
+	reader := NewBulkResultReader(ioutil.NopCloser(strings.NewReader(body)))
+	if reader.Next() || reader.Err() == nil {
+		t.Error("expected an error for a child without its parent")
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	for name, expected := range map[string]string{
+		"inventoryQuantity": "inventory_quantity",
+		"id":                "id",
+		"SKU":               "sku",
+		"productID":         "product_id",
+		"onlineStoreURL":    "online_store_url",
+		"option1":           "option1",
+	} {
+		if actual := snakeCase(name); actual != expected {
+			t.Errorf("expected %v for %v, got %v", expected, name, actual)
+		}
+	}
+}
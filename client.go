@@ -4,11 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"github.com/pkg/errors"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"strings"
+	"time"
 )
 
 type Client interface {
@@ -51,10 +52,22 @@ type Getter interface {
 	BuildGetUrl(Request) string
 }
 
+type Updater interface {
+	BuildUpdateUrl(Request) string
+	GetResourceName() string
+}
+
+// The number of times a 429 response is retried when a Limiter but no RetryPolicy is configured.
+const maxRateLimitRetries = 3
+
 type RestAdminClient struct {
 	Http    *http.Client
 	Logger  *log.Logger
 	Version ApiVersion
+	// Optional, when set requests are throttled per shop using Shopify's leaky bucket headers.
+	Limiter *RateLimiter
+	// Optional, when nil nothing is retried unless a Limiter is set, in which case 429 responses are.
+	Retry RetryPolicy
 }
 
 type Ctx struct {
@@ -65,6 +78,20 @@ type Ctx struct {
 	AutoPaginate bool
 }
 
+/*
+Returns a pointer to the value, for the optional boolean fields where false has to be sent.
+*/
+func Bool(value bool) *bool {
+	return &value
+}
+
+/*
+Returns a pointer to the value, for the optional text fields where an empty string has to be sent.
+*/
+func String(value string) *string {
+	return &value
+}
+
 type Request struct {
 	Context Ctx
 	Method  string
@@ -75,80 +102,140 @@ type Request struct {
 }
 
 func (r *RestAdminClient) Request(request Request) (result []byte, next string, err error) {
-	req, err := http.NewRequestWithContext(request.Context.Ctx, request.Method, request.Url, bytes.NewBuffer(request.Body))
-	if err != nil {
-		err = errors.WithMessagef(err, "unable to create request with input %+v", request)
-		return
-	}
+	result, links, err := r.RequestLinks(request)
+	next = links.Next()
+	return
+}
 
-	req.Header.Set("X-Shopify-Access-Token", request.Context.AccessToken)
-	req.Header.Set("Content-Type", "application/json")
+/*
+The same as Request but returns every relation from the Link header, including the previous page cursor.
+*/
+func (r *RestAdminClient) RequestLinks(request Request) (result []byte, links Links, err error) {
+	policy := r.retryPolicy()
+	start := time.Now()
 
-	for k, v := range request.Headers {
-		req.Header.Set(k, v)
-	}
+	var resp *http.Response
+	for attempt := 1; ; attempt++ {
+		resp, err = r.send(request)
+
+		wait, retry := policy.Retry(attempt, time.Since(start), request, resp, err)
+		if !retry {
+			break
+		}
 
-	resp, err := r.Http.Do(req)
+		if resp != nil {
+			_, _ = ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+		}
+
+		if sleepErr := sleepContext(request.Context.Ctx, wait); sleepErr != nil {
+			err = errors.WithMessagef(sleepErr, "cancelled while waiting to retry after %v attempts", attempt)
+			return
+		}
+	}
 	if err != nil {
-		err = errors.WithMessagef(err, "request failed %v", request)
 		return
 	}
-	next = ExtractNextCursorUrl(resp.Header.Get("Link"))
+	links = ParseLinkHeader(strings.Join(resp.Header.Values("Link"), ","))
 
 	defer resp.Body.Close()
 	result, err = ioutil.ReadAll(resp.Body)
 	if err != nil {
 		err = errors.WithMessage(err, "there was a problem was reading the body")
+		return
 	}
 
 	if resp.StatusCode >= 300 {
-		err = errors.New(string(result))
-		err = errors.WithMessagef(err, "received %v response", resp.StatusCode)
+		err = newShopifyError(request, resp, result)
 		return
 	}
 
 	return
 }
 
-func (r *RestAdminClient) List(context Ctx, options QueryParamStringer, resource Lister) (next string, err error) {
-	var request = Request{
-		Context: context,
-		Method:  "GET",
-		Version: r.Version,
+func (r *RestAdminClient) retryPolicy() RetryPolicy {
+	if r.Retry != nil {
+		return r.Retry
 	}
-	optionString, err := options.UrlOptionsString()
+
+	if r.Limiter != nil {
+		return rateLimitRetry{}
+	}
+
+	return noRetry{}
+}
+
+/*
+Sends a single attempt of the request, waiting on the rate limiter first when one is configured.
+*/
+func (r *RestAdminClient) send(request Request) (resp *http.Response, err error) {
+	req, err := http.NewRequestWithContext(request.Context.Ctx, request.Method, request.Url, bytes.NewBuffer(request.Body))
 	if err != nil {
+		err = errors.WithMessagef(err, "unable to create request with input %+v", request)
 		return
 	}
-	if context.CursorUrl != "" {
-		request.Url = context.CursorUrl
-	} else {
-		request.Url = BuildSimpleUrl(request, resource.GetResourceName()) + "?" + optionString
+
+	req.Header.Set("X-Shopify-Access-Token", request.Context.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	for k, v := range request.Headers {
+		req.Header.Set(k, v)
 	}
 
-	buf, next, err := r.Request(request)
+	if r.Limiter != nil {
+		err = r.Limiter.Wait(req.Context(), request.Context.ShopName)
+		if err != nil {
+			err = errors.WithMessage(err, "cancelled while waiting on the rate limiter")
+			return
+		}
+	}
 
-	err = json.Unmarshal(buf, &resource)
+	resp, err = r.Http.Do(req)
 	if err != nil {
-		fmt.Println(string(buf))
-		err = errors.WithMessage(err, "error while unmarshalling list response")
+		err = errors.WithMessagef(err, "request failed %v", request)
 		return
 	}
 
-	if context.AutoPaginate {
-		if next != "" {
-			context.CursorUrl = next
-		} else {
-			return
-		}
+	if r.Limiter != nil {
+		r.Limiter.Update(request.Context.ShopName, resp)
+	}
 
-		_, err = r.List(context, options, resource)
-		if err != nil {
-			err = errors.WithMessage(err, "failure during pagination...aborting")
-			return
+	return
+}
+
+/*
+Decodes the first page into the resource, or every page when the context has AutoPaginate set, which
+relies on the resource appending in its UnmarshalJSON. The returned next cursor is empty once the last
+page has been read.
+*/
+func (r *RestAdminClient) List(context Ctx, options QueryParamStringer, resource Lister) (next string, err error) {
+	iterator := r.NewIterator(context, options, func() Lister { return resource })
+	for iterator.Next() {
+		next = iterator.NextCursor()
+		if !context.AutoPaginate {
+			break
 		}
 	}
 
+	err = iterator.Err()
+	return
+}
+
+/*
+Uses the cursor url from the context when there is one, otherwise builds the first page url from the options.
+*/
+func buildListUrl(request Request, options QueryParamStringer, resource Lister) (url string, err error) {
+	if request.Context.CursorUrl != "" {
+		url = request.Context.CursorUrl
+		return
+	}
+
+	optionString, err := options.UrlOptionsString()
+	if err != nil {
+		return
+	}
+
+	url = BuildSimpleUrl(request, resource.GetResourceName()) + "?" + optionString
 	return
 }
 
@@ -162,6 +249,9 @@ func (r *RestAdminClient) Get(context Ctx, resource Getter) (err error) {
 	request.Url = resource.BuildGetUrl(request)
 
 	buf, _, err := r.Request(request)
+	if err != nil {
+		return
+	}
 
 	err = json.Unmarshal(buf, &resource)
 	if err != nil {
@@ -172,6 +262,9 @@ func (r *RestAdminClient) Get(context Ctx, resource Getter) (err error) {
 	return
 }
 
+/*
+Sends the original resource with a POST to its create url and decodes the response into the return resource.
+*/
 func (r *RestAdminClient) Create(context Ctx, returnResource Creator, originalResource Creator) (err error) {
 	var request = Request{
 		Context: context,
@@ -183,7 +276,36 @@ func (r *RestAdminClient) Create(context Ctx, returnResource Creator, originalRe
 		err = errors.WithMessage(err, "failure while marshaling the request data")
 		return
 	}
-	request.Url = returnResource.BuildCreateUrl(request)
+	request.Url = originalResource.BuildCreateUrl(request)
+	buf, _, err := r.Request(request)
+	if err != nil {
+		err = errors.WithMessage(err, "there was error while making the request")
+		return
+	}
+
+	err = json.Unmarshal(buf, &returnResource)
+	if err != nil {
+		err = errors.WithMessage(err, "error unmarshaling request")
+	}
+
+	return
+}
+
+/*
+Sends the original resource with a PUT to its update url and decodes the response into the return resource.
+*/
+func (r *RestAdminClient) Update(context Ctx, returnResource Updater, originalResource Updater) (err error) {
+	var request = Request{
+		Context: context,
+		Method:  "PUT",
+		Version: r.Version,
+	}
+	request.Body, err = json.Marshal(originalResource)
+	if err != nil {
+		err = errors.WithMessage(err, "failure while marshaling the request data")
+		return
+	}
+	request.Url = originalResource.BuildUpdateUrl(request)
 	buf, _, err := r.Request(request)
 	if err != nil {
 		err = errors.WithMessage(err, "there was error while making the request")
@@ -198,16 +320,79 @@ func (r *RestAdminClient) Create(context Ctx, returnResource Creator, originalRe
 	return
 }
 
+/*
+Requests the count endpoint of a resource path, for example orders/count.json. The options may be nil.
+*/
+func (r *RestAdminClient) Count(context Ctx, resource string, options QueryParamStringer) (count int, err error) {
+	var result struct {
+		Count int `json:"count"`
+	}
+	err = r.Send(context, "GET", resource+"/count", options, nil, &result)
+	count = result.Count
+
+	return
+}
+
+/*
+Sends the body as json to a resource path and decodes the json response into result. It covers
+the endpoints that don't fit List, Get, Create, Update and Delete, like orders/{id}/close.
+The options, body and result may be nil.
+*/
+func (r *RestAdminClient) Send(context Ctx, method string, resource string, options QueryParamStringer, body interface{}, result interface{}) (err error) {
+	var request = Request{
+		Context: context,
+		Method:  method,
+		Version: r.Version,
+	}
+	if body != nil {
+		request.Body, err = json.Marshal(body)
+		if err != nil {
+			err = errors.WithMessage(err, "failure while marshaling the request data")
+			return
+		}
+	}
+
+	request.Url = BuildSimpleUrl(request, resource)
+	if options != nil {
+		optionString, optionErr := options.UrlOptionsString()
+		if optionErr != nil {
+			err = optionErr
+			return
+		}
+		if optionString != "" {
+			request.Url += "?" + optionString
+		}
+	}
+
+	buf, _, err := r.Request(request)
+	if err != nil {
+		err = errors.WithMessagef(err, "there was error while making the %v request to %v", method, resource)
+		return
+	}
+
+	if result == nil || len(bytes.TrimSpace(buf)) == 0 {
+		return
+	}
+
+	err = json.Unmarshal(buf, result)
+	if err != nil {
+		err = errors.WithMessagef(err, "error unmarshaling the %v response", resource)
+	}
+
+	return
+}
+
 func (r *RestAdminClient) Delete(context Ctx, resource string, id int) (err error) {
 	var request = Request{
 		Context: context,
 		Method:  "DELETE",
+		Version: r.Version,
 	}
 	request.Url = BuildIdUrl(request, resource, id)
 
 	_, _, err = r.Request(request)
 	if err != nil {
-		err = errors.WithMessagef(err, "unable to delete webhook %v", id)
+		err = errors.WithMessagef(err, "unable to delete %v %v", resource, id)
 	}
 
 	return
@@ -0,0 +1,31 @@
+package shopify
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+)
+
+/*
+Starts a TLS test server with the handler and returns a client and request context pointed at it.
+*/
+func newTestClient(handler http.HandlerFunc) (*httptest.Server, RestAdminClient, Ctx) {
+	server := httptest.NewTLSServer(handler)
+	client := RestAdminClient{
+		Http:    server.Client(),
+		Logger:  log.New(os.Stdout, "", log.Ldate|log.Ltime|log.Lshortfile),
+		Version: VERSION_2020_10,
+	}
+
+	serverUrl, _ := url.Parse(server.URL)
+	requestContext := Ctx{
+		AccessToken: "thisisatoken",
+		ShopName:    serverUrl.Host,
+		Ctx:         context.Background(),
+	}
+
+	return server, client, requestContext
+}
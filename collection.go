@@ -1,41 +1,461 @@
 package shopify
 
+import (
+	"encoding/json"
+	"github.com/google/go-querystring/query"
+	"github.com/pkg/errors"
+	"strconv"
+)
+
+const (
+	CollectionSortAlphaAsc    = "alpha-asc"
+	CollectionSortAlphaDesc   = "alpha-desc"
+	CollectionSortBestSelling = "best-selling"
+	CollectionSortCreated     = "created"
+	CollectionSortCreatedDesc = "created-desc"
+	CollectionSortManual      = "manual"
+	CollectionSortPriceAsc    = "price-asc"
+	CollectionSortPriceDesc   = "price-desc"
+)
+
+/*
+Custom and smart collections share the same fields, Rules and Disjunctive are only used by smart collections
+and Collects only by custom collections. Published and Disjunctive are pointers so that false can be sent, for example Published: Bool(false)
+to hide a collection.
+*/
 type Collection struct {
-	BodyHtml                    string       `json:"body_html"`
-	Handle                      string       `json:"handle"`
-	Image                       Image        `json:"image"`
-	Id                          int          `json:"id"`
-	MetaFields                  []MetaFields `json:"metafields"`
-	Published                   bool         `json:"published"`
-	PublishedAt                 string       `json:"published_at"`
-	PublishedScope              string       `json:"published_scope"` // TODO this could be an enum value
-	SortOrder                   string       `json:"sort_order"`      // TODO this could be an enum value
-	TemplateSuffix              string       `json:"template_suffix"`
-	Title                       string       `json:"title"`
-	UpdatedAt                   string       `json:"updated_at"`
-	Rules                       Rules        `json:"rules,omitempty"`
-	Disjunctive                 bool         `json:"disjunctive,omitempty"`
+	BodyHtml                    string       `json:"body_html,omitempty"`
+	Handle                      string       `json:"handle,omitempty"`
+	Image                       *Image       `json:"image,omitempty"`
+	Id                          int          `json:"id,omitempty"`
+	MetaFields                  []MetaFields `json:"metafields,omitempty"`
+	Published                   *bool        `json:"published,omitempty"`
+	PublishedAt                 string       `json:"published_at,omitempty"`
+	PublishedScope              string       `json:"published_scope,omitempty"` // TODO this could be an enum value
+	SortOrder                   string       `json:"sort_order,omitempty"`
+	TemplateSuffix              string       `json:"template_suffix,omitempty"`
+	Title                       string       `json:"title,omitempty"`
+	UpdatedAt                   string       `json:"updated_at,omitempty"`
+	Rules                       []Rule       `json:"rules,omitempty"`
+	Disjunctive                 *bool        `json:"disjunctive,omitempty"`
 	ProductsManuallySortedCount int          `json:"products_manually_sorted_count,omitempty"`
+	Collects                    []Collect    `json:"collects,omitempty"`
 }
 
 type Image struct {
-	Src      string `json:"src"`
-	Alt      string `json:"alt"`
-	Width    int    `json:"width"`
-	Height   int    `json:"height"`
-	CreateAt string `json:"create_at"`
+	Src        string `json:"src,omitempty"`
+	Attachment string `json:"attachment,omitempty"`
+	Alt        string `json:"alt,omitempty"`
+	Width      int    `json:"width,omitempty"`
+	Height     int    `json:"height,omitempty"`
+	CreatedAt  string `json:"created_at,omitempty"`
 }
 
 type MetaFields struct {
-	Key       string `json:"key"`
-	Value     string `json:"value"`
-	ValueType string `json:"value_type"`
-	Namespace string `json:"namespace"`
+	Key       string `json:"key,omitempty"`
+	Value     string `json:"value,omitempty"`
+	ValueType string `json:"value_type,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type RuleColumn string
+
+const (
+	RuleColumnTitle                 RuleColumn = "title"
+	RuleColumnType                  RuleColumn = "type"
+	RuleColumnVendor                RuleColumn = "vendor"
+	RuleColumnVariantTitle          RuleColumn = "variant_title"
+	RuleColumnVariantPrice          RuleColumn = "variant_price"
+	RuleColumnVariantCompareAtPrice RuleColumn = "variant_compare_at_price"
+	RuleColumnVariantWeight         RuleColumn = "variant_weight"
+	RuleColumnVariantInventory      RuleColumn = "variant_inventory"
+	RuleColumnTag                   RuleColumn = "tag"
+	RuleColumnIsPriceReduced        RuleColumn = "is_price_reduced"
+)
+
+type RuleRelation string
+
+const (
+	RuleRelationEquals      RuleRelation = "equals"
+	RuleRelationNotEquals   RuleRelation = "not_equals"
+	RuleRelationGreaterThan RuleRelation = "greater_than"
+	RuleRelationLessThan    RuleRelation = "less_than"
+	RuleRelationStartsWith  RuleRelation = "starts_with"
+	RuleRelationEndsWith    RuleRelation = "ends_with"
+	RuleRelationContains    RuleRelation = "contains"
+	RuleRelationNotContains RuleRelation = "not_contains"
+	RuleRelationIsSet       RuleRelation = "is_set"
+	RuleRelationIsNotSet    RuleRelation = "is_not_set"
+)
+
+var (
+	textRuleRelations    = []RuleRelation{RuleRelationEquals, RuleRelationNotEquals, RuleRelationStartsWith, RuleRelationEndsWith, RuleRelationContains, RuleRelationNotContains}
+	numericRuleRelations = []RuleRelation{RuleRelationEquals, RuleRelationNotEquals, RuleRelationGreaterThan, RuleRelationLessThan}
+
+	/*
+		The relations Shopify accepts for each column.
+	*/
+	ruleRelations = map[RuleColumn][]RuleRelation{
+		RuleColumnTitle:                 textRuleRelations,
+		RuleColumnType:                  textRuleRelations,
+		RuleColumnVendor:                textRuleRelations,
+		RuleColumnVariantTitle:          textRuleRelations,
+		RuleColumnVariantPrice:          numericRuleRelations,
+		RuleColumnVariantCompareAtPrice: numericRuleRelations,
+		RuleColumnVariantWeight:         numericRuleRelations,
+		RuleColumnVariantInventory:      numericRuleRelations,
+		RuleColumnTag:                   {RuleRelationEquals},
+		RuleColumnIsPriceReduced:        {RuleRelationIsSet, RuleRelationIsNotSet},
+	}
+)
+
+/*
+A smart collection rule, products are in the collection when the column has the relation to the condition.
+*/
+type Rule struct {
+	Column    RuleColumn   `json:"column"`
+	Relation  RuleRelation `json:"relation"`
+	Condition string       `json:"condition"`
+}
+
+/*
+Checks the column and relation are a combination Shopify allows and that numeric columns have a numeric condition.
+*/
+func (r Rule) Validate() error {
+	relations, ok := ruleRelations[r.Column]
+	if !ok {
+		return errors.Errorf("%q is not a smart collection rule column", r.Column)
+	}
+
+	allowed := false
+	for _, relation := range relations {
+		if relation == r.Relation {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return errors.Errorf("the %q relation can't be used with the %q column", r.Relation, r.Column)
+	}
+
+	if r.Column == RuleColumnIsPriceReduced {
+		return nil
+	}
+
+	if r.Condition == "" {
+		return errors.Errorf("the %v rule on %q needs a condition", r.Relation, r.Column)
+	}
+
+	switch r.Column {
+	case RuleColumnVariantPrice, RuleColumnVariantCompareAtPrice, RuleColumnVariantWeight, RuleColumnVariantInventory:
+		if _, err := strconv.ParseFloat(r.Condition, 64); err != nil {
+			return errors.Errorf("the %q column needs a numeric condition, got %q", r.Column, r.Condition)
+		}
+	}
+
+	return nil
+}
+
+/*
+Validates every rule, the error names the first invalid one. A smart collection needs at least one rule.
+*/
+func ValidateRules(rules []Rule) error {
+	if len(rules) == 0 {
+		return errors.New("a smart collection needs at least one rule")
+	}
+
+	for i, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return errors.WithMessagef(err, "rule %v is invalid", i)
+		}
+	}
+
+	return nil
+}
+
+type CollectionRequestOptions struct {
+	Ids             []int    `url:"ids,omitempty,comma"`
+	Limit           int      `url:"limit,omitempty"`
+	SinceId         int      `url:"since_id,omitempty"`
+	Title           string   `url:"title,omitempty"`
+	ProductId       int      `url:"product_id,omitempty"`
+	Handle          string   `url:"handle,omitempty"`
+	UpdatedAtMin    string   `url:"updated_at_min,omitempty"`
+	UpdatedAtMax    string   `url:"updated_at_max,omitempty"`
+	PublishedAtMin  string   `url:"published_at_min,omitempty"`
+	PublishedAtMax  string   `url:"published_at_max,omitempty"`
+	PublishedStatus string   `url:"published_status,omitempty"`
+	Fields          []string `url:"fields,omitempty,comma"`
+}
+
+func (c CollectionRequestOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(c)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", c)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
+}
+
+type CustomCollectionWrapper struct {
+	CustomCollection *Collection `json:"custom_collection"`
+}
+
+func (c CustomCollectionWrapper) GetResourceName() string {
+	return "custom_collections"
+}
+
+func (c CustomCollectionWrapper) GetId() int {
+	return c.CustomCollection.Id
+}
+
+func (c CustomCollectionWrapper) BuildGetUrl(request Request) string {
+	return BuildIdUrl(request, c.GetResourceName(), c.GetId())
+}
+
+func (c CustomCollectionWrapper) BuildCreateUrl(request Request) string {
+	return BuildSimpleUrl(request, c.GetResourceName())
+}
+
+func (c CustomCollectionWrapper) BuildUpdateUrl(request Request) string {
+	return BuildIdUrl(request, c.GetResourceName(), c.GetId())
+}
+
+type CustomCollections struct {
+	CustomCollections []Collection `json:"custom_collections"`
+}
+
+type CustomCollectionsWrapper struct {
+	CustomCollections []Collection
+}
+
+func (c *CustomCollectionsWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper CustomCollections
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return
+	}
+
+	c.CustomCollections = append(c.CustomCollections, wrapper.CustomCollections...)
+	return
+}
+
+func (c CustomCollectionsWrapper) GetResourceName() string {
+	return "custom_collections"
+}
+
+func (r *RestAdminClient) CustomCollectionList(context Ctx, options CollectionRequestOptions) (results []Collection, next string, err error) {
+	var wrapper = &CustomCollectionsWrapper{}
+	next, err = r.List(context, options, wrapper)
+	results = wrapper.CustomCollections
+	return
+}
+
+type CustomCollectionIterator struct {
+	*Iterator
+}
+
+/*
+The custom collections on the current page.
+*/
+func (i *CustomCollectionIterator) CustomCollections() []Collection {
+	if i.Page() == nil {
+		return nil
+	}
+
+	return i.Page().(*CustomCollectionsWrapper).CustomCollections
+}
+
+func (r *RestAdminClient) CustomCollectionListIterator(context Ctx, options CollectionRequestOptions) *CustomCollectionIterator {
+	return &CustomCollectionIterator{r.NewIterator(context, options, func() Lister { return &CustomCollectionsWrapper{} })}
+}
+
+func (r *RestAdminClient) CustomCollectionGet(context Ctx, id int) (result *Collection, err error) {
+	wrapper := &CustomCollectionWrapper{CustomCollection: &Collection{Id: id}}
+	err = r.Get(context, wrapper)
+	result = wrapper.CustomCollection
+
+	return
+}
+
+func (r *RestAdminClient) CustomCollectionCount(context Ctx, options CollectionRequestOptions) (count int, err error) {
+	return r.Count(context, "custom_collections", options)
+}
+
+func (r *RestAdminClient) CustomCollectionCreate(context Ctx, request Collection) (result *Collection, err error) {
+	var returnWrapper = new(CustomCollectionWrapper)
+	err = r.Create(context, returnWrapper, CustomCollectionWrapper{CustomCollection: &request})
+	result = returnWrapper.CustomCollection
+
+	return
+}
+
+func (r *RestAdminClient) CustomCollectionUpdate(context Ctx, request Collection) (result *Collection, err error) {
+	var returnWrapper = new(CustomCollectionWrapper)
+	err = r.Update(context, returnWrapper, CustomCollectionWrapper{CustomCollection: &request})
+	result = returnWrapper.CustomCollection
+
+	return
+}
+
+func (r *RestAdminClient) CustomCollectionDelete(context Ctx, id int) (err error) {
+	return r.Delete(context, "custom_collections", id)
+}
+
+/*
+Puts the products of a custom collection in the given order by switching it to manual sorting and
+updating the position of each product's collect. Products that aren't in the collection are added.
+*/
+func (r *RestAdminClient) CustomCollectionOrder(context Ctx, id int, productIds []int) (result *Collection, err error) {
+	request := Collection{Id: id, SortOrder: CollectionSortManual, Collects: make([]Collect, 0, len(productIds))}
+	for i, productId := range productIds {
+		request.Collects = append(request.Collects, Collect{ProductId: productId, Position: i + 1})
+	}
+
+	return r.CustomCollectionUpdate(context, request)
+}
+
+type SmartCollectionWrapper struct {
+	SmartCollection *Collection `json:"smart_collection"`
+}
+
+func (s SmartCollectionWrapper) GetResourceName() string {
+	return "smart_collections"
+}
+
+func (s SmartCollectionWrapper) GetId() int {
+	return s.SmartCollection.Id
+}
+
+func (s SmartCollectionWrapper) BuildGetUrl(request Request) string {
+	return BuildIdUrl(request, s.GetResourceName(), s.GetId())
+}
+
+func (s SmartCollectionWrapper) BuildCreateUrl(request Request) string {
+	return BuildSimpleUrl(request, s.GetResourceName())
+}
+
+func (s SmartCollectionWrapper) BuildUpdateUrl(request Request) string {
+	return BuildIdUrl(request, s.GetResourceName(), s.GetId())
+}
+
+type SmartCollections struct {
+	SmartCollections []Collection `json:"smart_collections"`
+}
+
+type SmartCollectionsWrapper struct {
+	SmartCollections []Collection
+}
+
+func (s *SmartCollectionsWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper SmartCollections
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return
+	}
+
+	s.SmartCollections = append(s.SmartCollections, wrapper.SmartCollections...)
+	return
+}
+
+func (s SmartCollectionsWrapper) GetResourceName() string {
+	return "smart_collections"
+}
+
+func (r *RestAdminClient) SmartCollectionList(context Ctx, options CollectionRequestOptions) (results []Collection, next string, err error) {
+	var wrapper = &SmartCollectionsWrapper{}
+	next, err = r.List(context, options, wrapper)
+	results = wrapper.SmartCollections
+	return
+}
+
+type SmartCollectionIterator struct {
+	*Iterator
+}
+
+/*
+The smart collections on the current page.
+*/
+func (i *SmartCollectionIterator) SmartCollections() []Collection {
+	if i.Page() == nil {
+		return nil
+	}
+
+	return i.Page().(*SmartCollectionsWrapper).SmartCollections
+}
+
+func (r *RestAdminClient) SmartCollectionListIterator(context Ctx, options CollectionRequestOptions) *SmartCollectionIterator {
+	return &SmartCollectionIterator{r.NewIterator(context, options, func() Lister { return &SmartCollectionsWrapper{} })}
+}
+
+func (r *RestAdminClient) SmartCollectionGet(context Ctx, id int) (result *Collection, err error) {
+	wrapper := &SmartCollectionWrapper{SmartCollection: &Collection{Id: id}}
+	err = r.Get(context, wrapper)
+	result = wrapper.SmartCollection
+
+	return
+}
+
+func (r *RestAdminClient) SmartCollectionCount(context Ctx, options CollectionRequestOptions) (count int, err error) {
+	return r.Count(context, "smart_collections", options)
+}
+
+/*
+Creates the smart collection, the rules are validated first and nothing is sent when one is invalid.
+*/
+func (r *RestAdminClient) SmartCollectionCreate(context Ctx, request Collection) (result *Collection, err error) {
+	if err = ValidateRules(request.Rules); err != nil {
+		return
+	}
+
+	var returnWrapper = new(SmartCollectionWrapper)
+	err = r.Create(context, returnWrapper, SmartCollectionWrapper{SmartCollection: &request})
+	result = returnWrapper.SmartCollection
+
+	return
+}
+
+/*
+Updates the smart collection, when Rules is set they replace the existing rules and are validated first.
+*/
+func (r *RestAdminClient) SmartCollectionUpdate(context Ctx, request Collection) (result *Collection, err error) {
+	if len(request.Rules) > 0 {
+		if err = ValidateRules(request.Rules); err != nil {
+			return
+		}
+	}
+
+	var returnWrapper = new(SmartCollectionWrapper)
+	err = r.Update(context, returnWrapper, SmartCollectionWrapper{SmartCollection: &request})
+	result = returnWrapper.SmartCollection
+
+	return
+}
+
+func (r *RestAdminClient) SmartCollectionDelete(context Ctx, id int) (err error) {
+	return r.Delete(context, "smart_collections", id)
+}
+
+type SmartCollectionOrderOptions struct {
+	Products  []int  `url:"products[],omitempty"`
+	SortOrder string `url:"sort_order,omitempty"`
+}
+
+func (s SmartCollectionOrderOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(s)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", s)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
 }
 
-// TODO these could be enum values
-type Rules struct {
-	Column    string `json:"column"`
-	Relation  string `json:"relation"`
-	Condition string `json:"condition"`
+/*
+Changes the order of the products in a smart collection. Products puts them in that order and only
+works for a manually sorted collection, SortOrder switches the collection to one of the CollectionSort values.
+*/
+func (r *RestAdminClient) SmartCollectionOrder(context Ctx, id int, options SmartCollectionOrderOptions) (err error) {
+	return r.Send(context, "PUT", "smart_collections/"+strconv.Itoa(id)+"/order", options, nil, nil)
 }
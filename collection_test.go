@@ -0,0 +1,109 @@
+package shopify
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestRuleValidate(t *testing.T) {
+	valid := []Rule{
+		{Column: RuleColumnTitle, Relation: RuleRelationContains, Condition: "shirt"},
+		{Column: RuleColumnVariantPrice, Relation: RuleRelationGreaterThan, Condition: "10.50"},
+		{Column: RuleColumnTag, Relation: RuleRelationEquals, Condition: "sale"},
+		{Column: RuleColumnIsPriceReduced, Relation: RuleRelationIsSet},
+	}
+	if err := ValidateRules(valid); err != nil {
+		t.Errorf("expected the rules to be valid, got %v", err)
+	}
+
+	invalid := []Rule{
+		{Column: "color", Relation: RuleRelationEquals, Condition: "red"},
+		{Column: RuleColumnTag, Relation: RuleRelationContains, Condition: "sale"},
+		{Column: RuleColumnVendor, Relation: RuleRelationGreaterThan, Condition: "a"},
+		{Column: RuleColumnVariantWeight, Relation: RuleRelationLessThan, Condition: "heavy"},
+		{Column: RuleColumnIsPriceReduced, Relation: RuleRelationEquals, Condition: "true"},
+		{Column: RuleColumnType, Relation: RuleRelationEquals},
+	}
+	for _, rule := range invalid {
+		if err := rule.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", rule)
+		}
+	}
+
+	if err := ValidateRules(nil); err == nil {
+		t.Error("expected an error for a smart collection without rules")
+	}
+}
+
+func TestSmartCollectionCreateAndOrder(t *testing.T) {
+	requests := 0
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		switch {
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/smart_collections.json":
+			var raw map[string]map[string]interface{}
+			_ = json.NewDecoder(req.Body).Decode(&raw)
+			if disjunctive, sent := raw["smart_collection"]["disjunctive"]; !sent || disjunctive != false {
+				rw.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			rw.WriteHeader(http.StatusCreated)
+			_, _ = rw.Write([]byte(`{"smart_collection":{"id":1063001322,"title":"Macbooks","rules":[{"column":"vendor","relation":"equals","condition":"Apple"}]}}`))
+		case req.Method == "PUT" && req.URL.Path == "/admin/api/2020-10/smart_collections/1063001322/order.json" &&
+			req.URL.RawQuery == "products%5B%5D=921728736&products%5B%5D=632910392":
+			_, _ = rw.Write([]byte(`{}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	_, err := client.SmartCollectionCreate(requestContext, Collection{
+		Title: "Macbooks",
+		Rules: []Rule{{Column: RuleColumnVendor, Relation: RuleRelationLessThan, Condition: "Apple"}},
+	})
+	if err == nil || requests != 0 {
+		t.Fatalf("expected the invalid rule to be rejected before sending, got %v after %v requests", err, requests)
+	}
+
+	collection, err := client.SmartCollectionCreate(requestContext, Collection{
+		Title:       "Macbooks",
+		Rules:       []Rule{{Column: RuleColumnVendor, Relation: RuleRelationEquals, Condition: "Apple"}},
+		Disjunctive: Bool(false),
+	})
+	if err != nil || collection.Id != 1063001322 || collection.Rules[0].Column != RuleColumnVendor {
+		t.Fatalf("unexpected collection %+v %v", collection, err)
+	}
+
+	err = client.SmartCollectionOrder(requestContext, collection.Id, SmartCollectionOrderOptions{Products: []int{921728736, 632910392}})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCustomCollectionOrder(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != "PUT" || req.URL.Path != "/admin/api/2020-10/custom_collections/841564295.json" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var wrapper CustomCollectionWrapper
+		_ = json.NewDecoder(req.Body).Decode(&wrapper)
+		collects := wrapper.CustomCollection.Collects
+		if wrapper.CustomCollection.SortOrder != CollectionSortManual || len(collects) != 2 ||
+			collects[0].ProductId != 921728736 || collects[0].Position != 1 || collects[1].ProductId != 632910392 || collects[1].Position != 2 {
+			rw.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`{"custom_collection":{"id":841564295,"title":"IPods","sort_order":"manual"}}`))
+	})
+	defer server.Close()
+
+	collection, err := client.CustomCollectionOrder(requestContext, 841564295, []int{921728736, 632910392})
+	if err != nil || collection.SortOrder != CollectionSortManual {
+		t.Errorf("unexpected collection %+v %v", collection, err)
+	}
+}
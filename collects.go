@@ -3,71 +3,144 @@ package shopify
 import (
 	"encoding/json"
 	"github.com/google/go-querystring/query"
-	"io/ioutil"
-	"net/http"
+	"github.com/pkg/errors"
 )
 
+/*
+A collect puts a product in a custom collection. Only CollectionId and ProductId are needed to create one.
+*/
 type Collect struct {
-	CollectionId int    `json:"collection_id"`
-	CreateAt     string `json:"create_at"`
-	Featured     bool   `json:"featured"`
-	Id           int    `json:"id"`
-	Position     int    `json:"position"`
-	ProdutId     int    `json:"produt_id"`
-	SortValue    string `json:"sort_value"`
-	UpdatedAt    string `json:"updated_at"`
+	CollectionId int    `json:"collection_id,omitempty"`
+	CreatedAt    string `json:"created_at,omitempty"`
+	Id           int    `json:"id,omitempty"`
+	Position     int    `json:"position,omitempty"`
+	ProductId    int    `json:"product_id,omitempty"`
+	SortValue    string `json:"sort_value,omitempty"`
+	UpdatedAt    string `json:"updated_at,omitempty"`
 }
 
 type CollectWrapper struct {
-	Collects []Collect `json:"collect"`
+	Collect *Collect `json:"collect"`
+}
+
+func (c CollectWrapper) GetResourceName() string {
+	return "collects"
+}
+
+func (c CollectWrapper) GetId() int {
+	return c.Collect.Id
+}
+
+func (c CollectWrapper) BuildGetUrl(request Request) string {
+	return BuildIdUrl(request, c.GetResourceName(), c.GetId())
+}
+
+func (c CollectWrapper) BuildCreateUrl(request Request) string {
+	return BuildSimpleUrl(request, c.GetResourceName())
+}
+
+type Collects struct {
+	Collects []Collect `json:"collects"`
+}
+
+type CollectsWrapper struct {
+	Collects []Collect
+}
+
+func (c *CollectsWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper Collects
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return
+	}
+
+	c.Collects = append(c.Collects, wrapper.Collects...)
+	return
+}
+
+func (c CollectsWrapper) GetResourceName() string {
+	return "collects"
 }
 
 type CollectRequestOptions struct {
 	Limit        int      `url:"limit,omitempty"`
 	SinceId      int      `url:"since_id,omitempty"`
-	Fields       []string `url:"fields,omitempty"`
+	Fields       []string `url:"fields,omitempty,comma"`
 	CollectionId int      `url:"collection_id,omitempty"`
 	ProductId    int      `url:"product_id,omitempty"`
-	All          bool
+	// Deprecated: set AutoPaginate on the Ctx instead, it is kept as an alias for it.
+	All bool `url:"-"`
 }
 
-func (c *RestAdminClient) CollectList(details Ctx, options CollectRequestOptions) (result []Collect, err error) {
-	v, err := query.Values(options)
-	requestUrl := "https://" + details.ShopName + "/admin/api/2019-04/collects.json?" + v.Encode()
-	c.Logger.Println("This is the request url for the collects", requestUrl)
-
-	c.Logger.Printf("Requesting collects for shop %s using options %v\n", details.ShopName, options)
-
-	req, err := http.NewRequest("GET", requestUrl, nil)
+func (c CollectRequestOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(c)
 	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", c)
 		return
 	}
 
-	req.Header.Add("X-Shopify-Access-Token", details.AccessToken)
+	queryParams = values.Encode()
+	return
+}
 
-	resp, err := c.Http.Do(req)
-	if err != nil {
-		return
+func (r *RestAdminClient) CollectList(context Ctx, options CollectRequestOptions) (results []Collect, next string, err error) {
+	if options.All {
+		context.AutoPaginate = true
 	}
 
-	buf, _ := ioutil.ReadAll(resp.Body)
-	c.Logger.Println("This is the response from the request for the collects: ", string(buf))
-	wrapper := CollectWrapper{}
-	err = json.Unmarshal(buf, &wrapper)
-	if err != nil {
-		return
-	}
+	var wrapper = &CollectsWrapper{}
+	next, err = r.List(context, options, wrapper)
+	results = wrapper.Collects
+	return
+}
 
-	result = wrapper.Collects
+type CollectIterator struct {
+	*Iterator
+}
 
-	if len(result) < options.Limit || !options.All {
-		return
+/*
+The collects on the current page.
+*/
+func (i *CollectIterator) Collects() []Collect {
+	if i.Page() == nil {
+		return nil
 	}
 
-	options.SinceId = result[len(result)-1].Id
-	nextResult, err := c.CollectList(details, options)
+	return i.Page().(*CollectsWrapper).Collects
+}
+
+func (r *RestAdminClient) CollectListIterator(context Ctx, options CollectRequestOptions) *CollectIterator {
+	return &CollectIterator{r.NewIterator(context, options, func() Lister { return &CollectsWrapper{} })}
+}
 
-	result = append(result, nextResult...)
+func (r *RestAdminClient) CollectGet(context Ctx, id int) (result *Collect, err error) {
+	wrapper := &CollectWrapper{Collect: &Collect{Id: id}}
+	err = r.Get(context, wrapper)
+	result = wrapper.Collect
 
 	return
 }
+
+/*
+Counts the collects, filtered to a collection or a product when CollectionId or ProductId is set.
+*/
+func (r *RestAdminClient) CollectCount(context Ctx, options CollectRequestOptions) (count int, err error) {
+	return r.Count(context, "collects", options)
+}
+
+/*
+Adds the product to the custom collection.
+*/
+func (r *RestAdminClient) CollectCreate(context Ctx, collectionId int, productId int) (result *Collect, err error) {
+	var returnWrapper = new(CollectWrapper)
+	err = r.Create(context, returnWrapper, CollectWrapper{Collect: &Collect{CollectionId: collectionId, ProductId: productId}})
+	result = returnWrapper.Collect
+
+	return
+}
+
+/*
+Removes the product from the custom collection the collect belongs to.
+*/
+func (r *RestAdminClient) CollectDelete(context Ctx, id int) (err error) {
+	return r.Delete(context, "collects", id)
+}
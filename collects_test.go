@@ -0,0 +1,45 @@
+package shopify
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestCollectListAndCreate(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/admin/api/2020-10/collects.json" && req.URL.RawQuery == "collection_id=841564295":
+			_, _ = rw.Write([]byte(`{"collects":[{"id":455204334,"collection_id":841564295,"product_id":632910392,"position":1}]}`))
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/collects.json":
+			var wrapper CollectWrapper
+			_ = json.NewDecoder(req.Body).Decode(&wrapper)
+			if wrapper.Collect.ProductId != 921728736 || wrapper.Collect.CollectionId != 841564295 {
+				rw.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			wrapper.Collect.Id = 1071559575
+			rw.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(rw).Encode(wrapper)
+		case req.Method == "DELETE" && req.URL.Path == "/admin/api/2020-10/collects/1071559575.json":
+			_, _ = rw.Write([]byte(`{}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	collects, _, err := client.CollectList(requestContext, CollectRequestOptions{CollectionId: 841564295})
+	if err != nil || len(collects) != 1 || collects[0].ProductId != 632910392 {
+		t.Fatalf("unexpected collects %+v %v", collects, err)
+	}
+
+	collect, err := client.CollectCreate(requestContext, 841564295, 921728736)
+	if err != nil || collect.Id != 1071559575 || collect.ProductId != 921728736 {
+		t.Fatalf("unexpected collect %+v %v", collect, err)
+	}
+
+	if err = client.CollectDelete(requestContext, collect.Id); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,372 @@
+package shopify
+
+import (
+	"encoding/json"
+	"github.com/google/go-querystring/query"
+	"github.com/pkg/errors"
+	"strconv"
+)
+
+type Customer struct {
+	AcceptsMarketing          bool              `json:"accepts_marketing,omitempty"`
+	AcceptsMarketingUpdatedAt string            `json:"accepts_marketing_updated_at,omitempty"`
+	Addresses                 []CustomerAddress `json:"addresses,omitempty"`
+	Currency                  string            `json:"currency,omitempty"`
+	CreatedAt                 string            `json:"created_at,omitempty"`
+	DefaultAddress            *CustomerAddress  `json:"default_address,omitempty"`
+	Email                     string            `json:"email,omitempty"`
+	FirstName                 string            `json:"first_name,omitempty"`
+	Id                        int               `json:"id,omitempty"`
+	LastName                  string            `json:"last_name,omitempty"`
+	LastOrderId               int               `json:"last_order_id,omitempty"`
+	LastOrderName             string            `json:"last_order_name,omitempty"`
+	MarketingOptInLevel       string            `json:"marketing_opt_in_level,omitempty"`
+	MultipassIdentifier       string            `json:"multipass_identifier,omitempty"`
+	Note                      string            `json:"note,omitempty"`
+	OrdersCount               int               `json:"orders_count,omitempty"`
+	Phone                     string            `json:"phone,omitempty"`
+	State                     string            `json:"state,omitempty"`
+	Tags                      string            `json:"tags,omitempty"`
+	TaxExempt                 bool              `json:"tax_exempt,omitempty"`
+	TaxExemptions             []string          `json:"tax_exemptions,omitempty"`
+	TotalSpent                string            `json:"total_spent,omitempty"`
+	UpdatedAt                 string            `json:"updated_at,omitempty"`
+	VerifiedEmail             bool              `json:"verified_email,omitempty"`
+}
+
+type CustomerAddress struct {
+	Address
+	CustomerId int  `json:"customer_id,omitempty"`
+	Default    bool `json:"default,omitempty"`
+	Id         int  `json:"id,omitempty"`
+}
+
+/*
+The invite email sent by CustomerSendInvite, empty fields use the shop's defaults.
+*/
+type CustomerInvite struct {
+	Bcc           []string `json:"bcc,omitempty"`
+	CustomMessage string   `json:"custom_message,omitempty"`
+	From          string   `json:"from,omitempty"`
+	Subject       string   `json:"subject,omitempty"`
+	To            string   `json:"to,omitempty"`
+}
+
+type CustomerWrapper struct {
+	Customer *Customer `json:"customer"`
+}
+
+func (c CustomerWrapper) GetResourceName() string {
+	return "customers"
+}
+
+func (c CustomerWrapper) GetId() int {
+	return c.Customer.Id
+}
+
+func (c CustomerWrapper) BuildGetUrl(request Request) string {
+	return BuildIdUrl(request, c.GetResourceName(), c.GetId())
+}
+
+func (c CustomerWrapper) BuildCreateUrl(request Request) string {
+	return BuildSimpleUrl(request, c.GetResourceName())
+}
+
+func (c CustomerWrapper) BuildUpdateUrl(request Request) string {
+	return BuildIdUrl(request, c.GetResourceName(), c.GetId())
+}
+
+type Customers struct {
+	Customers []Customer `json:"customers"`
+}
+
+type CustomersWrapper struct {
+	Customers []Customer
+}
+
+func (c *CustomersWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper Customers
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return
+	}
+
+	c.Customers = append(c.Customers, wrapper.Customers...)
+	return
+}
+
+func (c CustomersWrapper) GetResourceName() string {
+	return "customers"
+}
+
+/*
+The results of the search endpoint have the same shape as the customer list.
+*/
+type CustomerSearchWrapper struct {
+	CustomersWrapper
+}
+
+func (c CustomerSearchWrapper) GetResourceName() string {
+	return "customers/search"
+}
+
+type CustomerAddresses struct {
+	Addresses []CustomerAddress `json:"addresses"`
+}
+
+type CustomerAddressesWrapper struct {
+	CustomerId int
+	Addresses  []CustomerAddress
+}
+
+func (c *CustomerAddressesWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper CustomerAddresses
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return
+	}
+
+	c.Addresses = append(c.Addresses, wrapper.Addresses...)
+	return
+}
+
+func (c CustomerAddressesWrapper) GetResourceName() string {
+	return customerPath(c.CustomerId) + "/addresses"
+}
+
+type customerAddressWrapper struct {
+	Address         *CustomerAddress `json:"address,omitempty"`
+	CustomerAddress *CustomerAddress `json:"customer_address,omitempty"`
+}
+
+type CustomerRequestOptions struct {
+	CreatedAtMax string   `url:"created_at_max,omitempty"`
+	CreatedAtMin string   `url:"created_at_min,omitempty"`
+	Fields       []string `url:"fields,omitempty,comma"`
+	Ids          []int    `url:"ids,omitempty,comma"`
+	Limit        int      `url:"limit,omitempty"`
+	SinceId      int      `url:"since_id,omitempty"`
+	UpdatedAtMax string   `url:"updated_at_max,omitempty"`
+	UpdatedAtMin string   `url:"updated_at_min,omitempty"`
+}
+
+func (c CustomerRequestOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(c)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", c)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
+}
+
+/*
+Query uses Shopify's customer search syntax, for example "email:bob@example.com" or "country:Canada".
+Order defaults to last_order_date DESC.
+*/
+type CustomerSearchOptions struct {
+	Fields []string `url:"fields,omitempty,comma"`
+	Limit  int      `url:"limit,omitempty"`
+	Order  string   `url:"order,omitempty"`
+	Query  string   `url:"query,omitempty"`
+}
+
+func (c CustomerSearchOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(c)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", c)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
+}
+
+type CustomerAddressRequestOptions struct {
+	Limit int `url:"limit,omitempty"`
+}
+
+func (c CustomerAddressRequestOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(c)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", c)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
+}
+
+type customerAddressSetOptions struct {
+	AddressIds []int  `url:"address_ids[]"`
+	Operation  string `url:"operation"`
+}
+
+func (c customerAddressSetOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(c)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", c)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
+}
+
+func (r *RestAdminClient) CustomerList(context Ctx, options CustomerRequestOptions) (results []Customer, next string, err error) {
+	var wrapper = &CustomersWrapper{}
+	next, err = r.List(context, options, wrapper)
+	results = wrapper.Customers
+	return
+}
+
+type CustomerIterator struct {
+	*Iterator
+}
+
+/*
+The customers on the current page.
+*/
+func (i *CustomerIterator) Customers() []Customer {
+	switch page := i.Page().(type) {
+	case *CustomersWrapper:
+		return page.Customers
+	case *CustomerSearchWrapper:
+		return page.Customers
+	}
+
+	return nil
+}
+
+func (r *RestAdminClient) CustomerListIterator(context Ctx, options CustomerRequestOptions) *CustomerIterator {
+	return &CustomerIterator{r.NewIterator(context, options, func() Lister { return &CustomersWrapper{} })}
+}
+
+func (r *RestAdminClient) CustomerSearch(context Ctx, options CustomerSearchOptions) (results []Customer, next string, err error) {
+	var wrapper = &CustomerSearchWrapper{}
+	next, err = r.List(context, options, wrapper)
+	results = wrapper.Customers
+	return
+}
+
+func (r *RestAdminClient) CustomerSearchIterator(context Ctx, options CustomerSearchOptions) *CustomerIterator {
+	return &CustomerIterator{r.NewIterator(context, options, func() Lister { return &CustomerSearchWrapper{} })}
+}
+
+func (r *RestAdminClient) CustomerGet(context Ctx, id int) (result *Customer, err error) {
+	wrapper := &CustomerWrapper{Customer: &Customer{Id: id}}
+	err = r.Get(context, wrapper)
+	result = wrapper.Customer
+
+	return
+}
+
+func (r *RestAdminClient) CustomerCount(context Ctx) (count int, err error) {
+	return r.Count(context, "customers", nil)
+}
+
+func (r *RestAdminClient) CustomerCreate(context Ctx, request Customer) (result *Customer, err error) {
+	var returnWrapper = new(CustomerWrapper)
+	err = r.Create(context, returnWrapper, CustomerWrapper{Customer: &request})
+	result = returnWrapper.Customer
+
+	return
+}
+
+func (r *RestAdminClient) CustomerUpdate(context Ctx, request Customer) (result *Customer, err error) {
+	var returnWrapper = new(CustomerWrapper)
+	err = r.Update(context, returnWrapper, CustomerWrapper{Customer: &request})
+	result = returnWrapper.Customer
+
+	return
+}
+
+/*
+Shopify refuses to delete customers that have placed orders.
+*/
+func (r *RestAdminClient) CustomerDelete(context Ctx, id int) (err error) {
+	return r.Delete(context, "customers", id)
+}
+
+func (r *RestAdminClient) CustomerAddressList(context Ctx, customerId int, options CustomerAddressRequestOptions) (results []CustomerAddress, next string, err error) {
+	var wrapper = &CustomerAddressesWrapper{CustomerId: customerId}
+	next, err = r.List(context, options, wrapper)
+	results = wrapper.Addresses
+	return
+}
+
+type CustomerAddressIterator struct {
+	*Iterator
+}
+
+/*
+The addresses on the current page.
+*/
+func (i *CustomerAddressIterator) Addresses() []CustomerAddress {
+	if i.Page() == nil {
+		return nil
+	}
+
+	return i.Page().(*CustomerAddressesWrapper).Addresses
+}
+
+func (r *RestAdminClient) CustomerAddressListIterator(context Ctx, customerId int, options CustomerAddressRequestOptions) *CustomerAddressIterator {
+	return &CustomerAddressIterator{r.NewIterator(context, options, func() Lister { return &CustomerAddressesWrapper{CustomerId: customerId} })}
+}
+
+func (r *RestAdminClient) CustomerAddressCreate(context Ctx, customerId int, address CustomerAddress) (result *CustomerAddress, err error) {
+	var wrapper customerAddressWrapper
+	err = r.Send(context, "POST", customerPath(customerId)+"/addresses", nil, customerAddressWrapper{Address: &address}, &wrapper)
+	result = wrapper.CustomerAddress
+
+	return
+}
+
+func (r *RestAdminClient) CustomerAddressSetDefault(context Ctx, customerId int, addressId int) (result *CustomerAddress, err error) {
+	var wrapper customerAddressWrapper
+	resource := customerPath(customerId) + "/addresses/" + strconv.Itoa(addressId) + "/default"
+	err = r.Send(context, "PUT", resource, nil, struct{}{}, &wrapper)
+	result = wrapper.CustomerAddress
+
+	return
+}
+
+/*
+Deletes several addresses of a customer at once, the default address can't be deleted.
+*/
+func (r *RestAdminClient) CustomerAddressBulkDelete(context Ctx, customerId int, addressIds []int) (err error) {
+	options := customerAddressSetOptions{AddressIds: addressIds, Operation: "destroy"}
+	err = r.Send(context, "PUT", customerPath(customerId)+"/addresses/set", options, struct{}{}, nil)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to delete addresses %v of customer %v", addressIds, customerId)
+	}
+
+	return
+}
+
+func (r *RestAdminClient) CustomerSendInvite(context Ctx, customerId int, invite CustomerInvite) (result CustomerInvite, err error) {
+	var wrapper struct {
+		CustomerInvite CustomerInvite `json:"customer_invite"`
+	}
+	wrapper.CustomerInvite = invite
+	err = r.Send(context, "POST", customerPath(customerId)+"/send_invite", nil, wrapper, &wrapper)
+	result = wrapper.CustomerInvite
+
+	return
+}
+
+/*
+Creates a one time url the customer can use to activate their account, it expires after 30 days.
+*/
+func (r *RestAdminClient) CustomerAccountActivationUrl(context Ctx, customerId int) (activationUrl string, err error) {
+	var result struct {
+		AccountActivationUrl string `json:"account_activation_url"`
+	}
+	err = r.Send(context, "POST", customerPath(customerId)+"/account_activation_url", nil, struct{}{}, &result)
+	activationUrl = result.AccountActivationUrl
+
+	return
+}
+
+func customerPath(id int) string {
+	return "customers/" + strconv.Itoa(id)
+}
@@ -0,0 +1,57 @@
+package shopify
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCustomerSearchAndAddresses(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/admin/api/2020-10/customers/search.json" && req.URL.Query().Get("query") == "email:bob@example.com":
+			_, _ = rw.Write([]byte(`{"customers":[{"id":207119551,"email":"bob@example.com","default_address":{"id":207119551,"default":true,"city":"Ottawa"}}]}`))
+		case req.Method == "PUT" && req.URL.Path == "/admin/api/2020-10/customers/207119551/addresses/set.json" &&
+			req.URL.RawQuery == "address_ids%5B%5D=1053317288&address_ids%5B%5D=1053317289&operation=destroy":
+			_, _ = rw.Write([]byte(`{}`))
+		case req.Method == "GET" && req.URL.Path == "/admin/api/2020-10/customers/207119551/addresses.json" && req.URL.RawQuery == "limit=1":
+			rw.Header().Set("Link", "<https://"+req.Host+"/admin/api/2020-10/customers/207119551/addresses.json?limit=1&page_info=two>; rel=\"next\"")
+			_, _ = rw.Write([]byte(`{"addresses":[{"id":207119551,"city":"Ottawa"}]}`))
+		case req.Method == "GET" && req.URL.Path == "/admin/api/2020-10/customers/207119551/addresses.json" && req.URL.RawQuery == "limit=1&page_info=two":
+			_, _ = rw.Write([]byte(`{"addresses":[{"id":1053317288,"city":"Toronto"}]}`))
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/customers/207119551/account_activation_url.json":
+			_, _ = rw.Write([]byte(`{"account_activation_url":"https://example.myshopify.com/account/activate/207119551/abc"}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	customers, _, err := client.CustomerSearch(requestContext, CustomerSearchOptions{Query: "email:bob@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(customers) != 1 || customers[0].DefaultAddress.City != "Ottawa" || !customers[0].DefaultAddress.Default {
+		t.Errorf("unexpected search results %+v", customers)
+	}
+
+	var cities []string
+	addresses := client.CustomerAddressListIterator(requestContext, 207119551, CustomerAddressRequestOptions{Limit: 1})
+	for addresses.Next() {
+		for _, address := range addresses.Addresses() {
+			cities = append(cities, address.City)
+		}
+	}
+	if addresses.Err() != nil || len(cities) != 2 || cities[1] != "Toronto" {
+		t.Errorf("unexpected address pages %v %v", cities, addresses.Err())
+	}
+
+	err = client.CustomerAddressBulkDelete(requestContext, 207119551, []int{1053317288, 1053317289})
+	if err != nil {
+		t.Error(err)
+	}
+
+	activationUrl, err := client.CustomerAccountActivationUrl(requestContext, 207119551)
+	if err != nil || activationUrl != "https://example.myshopify.com/account/activate/207119551/abc" {
+		t.Errorf("unexpected activation url %v %v", activationUrl, err)
+	}
+}
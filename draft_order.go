@@ -0,0 +1,297 @@
+package shopify
+
+import (
+	"encoding/json"
+	"github.com/google/go-querystring/query"
+	"github.com/pkg/errors"
+	"strconv"
+)
+
+const (
+	DraftOrderStatusOpen        = "open"
+	DraftOrderStatusInvoiceSent = "invoice_sent"
+	DraftOrderStatusCompleted   = "completed"
+)
+
+const (
+	DiscountValueTypeFixedAmount = "fixed_amount"
+	DiscountValueTypePercentage  = "percentage"
+)
+
+type DraftOrder struct {
+	AppliedDiscount           *AppliedDiscount        `json:"applied_discount,omitempty"`
+	BillingAddress            *Address                `json:"billing_address,omitempty"`
+	CompletedAt               string                  `json:"completed_at,omitempty"`
+	CreatedAt                 string                  `json:"created_at,omitempty"`
+	Currency                  string                  `json:"currency,omitempty"`
+	Customer                  *Customer               `json:"customer,omitempty"`
+	Email                     string                  `json:"email,omitempty"`
+	Id                        int                     `json:"id,omitempty"`
+	InvoiceSentAt             string                  `json:"invoice_sent_at,omitempty"`
+	InvoiceUrl                string                  `json:"invoice_url,omitempty"`
+	LineItems                 []DraftOrderLineItem    `json:"line_items,omitempty"`
+	Name                      string                  `json:"name,omitempty"`
+	Note                      string                  `json:"note,omitempty"`
+	NoteAttributes            []NoteAttribute         `json:"note_attributes,omitempty"`
+	OrderId                   int                     `json:"order_id,omitempty"`
+	ShippingAddress           *Address                `json:"shipping_address,omitempty"`
+	ShippingLine              *DraftOrderShippingLine `json:"shipping_line,omitempty"`
+	Status                    string                  `json:"status,omitempty"`
+	SubtotalPrice             Money                   `json:"subtotal_price,omitempty"`
+	Tags                      string                  `json:"tags,omitempty"`
+	TaxExempt                 *bool                   `json:"tax_exempt,omitempty"`
+	TaxesIncluded             bool                    `json:"taxes_included,omitempty"`
+	TaxLines                  []TaxLine               `json:"tax_lines,omitempty"`
+	TotalPrice                Money                   `json:"total_price,omitempty"`
+	TotalTax                  Money                   `json:"total_tax,omitempty"`
+	UpdatedAt                 string                  `json:"updated_at,omitempty"`
+	UseCustomerDefaultAddress bool                    `json:"use_customer_default_address,omitempty"`
+}
+
+/*
+A line item of a draft order. Items for a product variant only need VariantId and Quantity, custom
+items have no variant and need a Title and Price instead. Shopify makes custom items taxable and
+requiring shipping unless Taxable or RequiresShipping is set to Bool(false).
+*/
+type DraftOrderLineItem struct {
+	AppliedDiscount    *AppliedDiscount `json:"applied_discount,omitempty"`
+	Custom             bool             `json:"custom,omitempty"`
+	FulfillmentService string           `json:"fulfillment_service,omitempty"`
+	GiftCard           bool             `json:"gift_card,omitempty"`
+	Grams              int              `json:"grams,omitempty"`
+	Id                 int              `json:"id,omitempty"`
+	Name               string           `json:"name,omitempty"`
+	Price              Money            `json:"price,omitempty"`
+	ProductId          int              `json:"product_id,omitempty"`
+	Properties         []NoteAttribute  `json:"properties,omitempty"`
+	Quantity           int              `json:"quantity,omitempty"`
+	RequiresShipping   *bool            `json:"requires_shipping,omitempty"`
+	Sku                string           `json:"sku,omitempty"`
+	Taxable            *bool            `json:"taxable,omitempty"`
+	TaxLines           []TaxLine        `json:"tax_lines,omitempty"`
+	Title              string           `json:"title,omitempty"`
+	VariantId          int              `json:"variant_id,omitempty"`
+	VariantTitle       string           `json:"variant_title,omitempty"`
+	Vendor             string           `json:"vendor,omitempty"`
+}
+
+/*
+A discount on a draft order or one of its line items. Value is the percentage or the fixed amount
+depending on ValueType, Amount is the resulting discount worked out by Shopify.
+*/
+type AppliedDiscount struct {
+	Amount      Money  `json:"amount,omitempty"`
+	Description string `json:"description,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Value       Money  `json:"value,omitempty"`
+	ValueType   string `json:"value_type,omitempty"`
+}
+
+/*
+The shipping of a draft order, either a shop rate by Handle or a custom rate with a Title and Price.
+*/
+type DraftOrderShippingLine struct {
+	Custom bool   `json:"custom,omitempty"`
+	Handle string `json:"handle,omitempty"`
+	Price  Money  `json:"price,omitempty"`
+	Title  string `json:"title,omitempty"`
+}
+
+/*
+The invoice email sent by DraftOrderSendInvoice, empty fields use the shop's defaults.
+*/
+type DraftOrderInvoice struct {
+	Bcc           []string `json:"bcc,omitempty"`
+	CustomMessage string   `json:"custom_message,omitempty"`
+	From          string   `json:"from,omitempty"`
+	Subject       string   `json:"subject,omitempty"`
+	To            string   `json:"to,omitempty"`
+}
+
+type DraftOrderWrapper struct {
+	DraftOrder *DraftOrder `json:"draft_order"`
+}
+
+func (d DraftOrderWrapper) GetResourceName() string {
+	return "draft_orders"
+}
+
+func (d DraftOrderWrapper) GetId() int {
+	return d.DraftOrder.Id
+}
+
+func (d DraftOrderWrapper) BuildGetUrl(request Request) string {
+	return BuildIdUrl(request, d.GetResourceName(), d.GetId())
+}
+
+func (d DraftOrderWrapper) BuildCreateUrl(request Request) string {
+	return BuildSimpleUrl(request, d.GetResourceName())
+}
+
+func (d DraftOrderWrapper) BuildUpdateUrl(request Request) string {
+	return BuildIdUrl(request, d.GetResourceName(), d.GetId())
+}
+
+type DraftOrders struct {
+	DraftOrders []DraftOrder `json:"draft_orders"`
+}
+
+type DraftOrdersWrapper struct {
+	DraftOrders []DraftOrder
+}
+
+func (d *DraftOrdersWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper DraftOrders
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return
+	}
+
+	d.DraftOrders = append(d.DraftOrders, wrapper.DraftOrders...)
+	return
+}
+
+func (d DraftOrdersWrapper) GetResourceName() string {
+	return "draft_orders"
+}
+
+type DraftOrderRequestOptions struct {
+	Fields       []string `url:"fields,omitempty,comma"`
+	Ids          []int    `url:"ids,omitempty,comma"`
+	Limit        int      `url:"limit,omitempty"`
+	SinceId      int      `url:"since_id,omitempty"`
+	Status       string   `url:"status,omitempty"`
+	UpdatedAtMin string   `url:"updated_at_min,omitempty"`
+	UpdatedAtMax string   `url:"updated_at_max,omitempty"`
+}
+
+func (d DraftOrderRequestOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(d)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", d)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
+}
+
+func (r *RestAdminClient) DraftOrderList(context Ctx, options DraftOrderRequestOptions) (results []DraftOrder, next string, err error) {
+	var wrapper = &DraftOrdersWrapper{}
+	next, err = r.List(context, options, wrapper)
+	results = wrapper.DraftOrders
+	return
+}
+
+type DraftOrderIterator struct {
+	*Iterator
+}
+
+/*
+The draft orders on the current page.
+*/
+func (i *DraftOrderIterator) DraftOrders() []DraftOrder {
+	if i.Page() == nil {
+		return nil
+	}
+
+	return i.Page().(*DraftOrdersWrapper).DraftOrders
+}
+
+func (r *RestAdminClient) DraftOrderListIterator(context Ctx, options DraftOrderRequestOptions) *DraftOrderIterator {
+	return &DraftOrderIterator{r.NewIterator(context, options, func() Lister { return &DraftOrdersWrapper{} })}
+}
+
+func (r *RestAdminClient) DraftOrderGet(context Ctx, id int) (result *DraftOrder, err error) {
+	wrapper := &DraftOrderWrapper{DraftOrder: &DraftOrder{Id: id}}
+	err = r.Get(context, wrapper)
+	result = wrapper.DraftOrder
+
+	return
+}
+
+/*
+Counts the draft orders matching the status and updated_at filters of the options, the paging options are ignored.
+*/
+func (r *RestAdminClient) DraftOrderCount(context Ctx, options DraftOrderRequestOptions) (count int, err error) {
+	return r.Count(context, "draft_orders", options)
+}
+
+func (r *RestAdminClient) DraftOrderCreate(context Ctx, request DraftOrder) (result *DraftOrder, err error) {
+	var returnWrapper = new(DraftOrderWrapper)
+	err = r.Create(context, returnWrapper, DraftOrderWrapper{DraftOrder: &request})
+	result = returnWrapper.DraftOrder
+
+	return
+}
+
+/*
+Updates the draft order, including LineItems replaces all of them.
+*/
+func (r *RestAdminClient) DraftOrderUpdate(context Ctx, request DraftOrder) (result *DraftOrder, err error) {
+	var returnWrapper = new(DraftOrderWrapper)
+	err = r.Update(context, returnWrapper, DraftOrderWrapper{DraftOrder: &request})
+	result = returnWrapper.DraftOrder
+
+	return
+}
+
+func (r *RestAdminClient) DraftOrderDelete(context Ctx, id int) (err error) {
+	return r.Delete(context, "draft_orders", id)
+}
+
+/*
+Emails the invoice for the draft order to the customer, returning the invoice as sent.
+*/
+func (r *RestAdminClient) DraftOrderSendInvoice(context Ctx, id int, invoice DraftOrderInvoice) (result *DraftOrderInvoice, err error) {
+	body := struct {
+		DraftOrderInvoice DraftOrderInvoice `json:"draft_order_invoice"`
+	}{invoice}
+
+	var wrapper struct {
+		DraftOrderInvoice *DraftOrderInvoice `json:"draft_order_invoice"`
+	}
+	err = r.Send(context, "POST", draftOrderPath(id)+"/send_invoice", nil, body, &wrapper)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to send the invoice for draft order %v", id)
+		return
+	}
+	result = wrapper.DraftOrderInvoice
+
+	return
+}
+
+type draftOrderCompleteOptions struct {
+	PaymentPending bool `url:"payment_pending,omitempty"`
+}
+
+func (d draftOrderCompleteOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(d)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", d)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
+}
+
+/*
+Turns the draft order into an order. With paymentPending the order is created unpaid, for payment
+terms such as net 30, otherwise it is marked as paid. The OrderId of the result is the new order.
+*/
+func (r *RestAdminClient) DraftOrderComplete(context Ctx, id int, paymentPending bool) (result *DraftOrder, err error) {
+	var wrapper DraftOrderWrapper
+	options := draftOrderCompleteOptions{PaymentPending: paymentPending}
+	err = r.Send(context, "PUT", draftOrderPath(id)+"/complete", options, nil, &wrapper)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to complete draft order %v", id)
+		return
+	}
+	result = wrapper.DraftOrder
+
+	return
+}
+
+func draftOrderPath(id int) string {
+	return "draft_orders/" + strconv.Itoa(id)
+}
@@ -0,0 +1,68 @@
+package shopify
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestDraftOrderQuoteFlow(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/draft_orders.json":
+			var wrapper DraftOrderWrapper
+			_ = json.NewDecoder(req.Body).Decode(&wrapper)
+			draftOrder := wrapper.DraftOrder
+			if len(draftOrder.LineItems) != 2 || draftOrder.LineItems[1].Price != "20.00" || draftOrder.LineItems[1].VariantId != 0 ||
+				draftOrder.LineItems[1].Taxable == nil || *draftOrder.LineItems[1].Taxable || draftOrder.LineItems[1].RequiresShipping == nil ||
+				*draftOrder.LineItems[1].RequiresShipping || draftOrder.TaxExempt == nil || *draftOrder.TaxExempt ||
+				draftOrder.AppliedDiscount.ValueType != DiscountValueTypePercentage || draftOrder.ShippingLine.Price != "10.00" {
+				rw.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			draftOrder.Id = 994118539
+			draftOrder.Status = DraftOrderStatusOpen
+			rw.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(rw).Encode(wrapper)
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/draft_orders/994118539/send_invoice.json":
+			var body map[string]DraftOrderInvoice
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			_ = json.NewEncoder(rw).Encode(body)
+		case req.Method == "PUT" && req.URL.Path == "/admin/api/2020-10/draft_orders/994118539/complete.json" && req.URL.RawQuery == "payment_pending=true":
+			_, _ = rw.Write([]byte(`{"draft_order":{"id":994118539,"order_id":450789469,"status":"completed"}}`))
+		case req.Method == "GET" && req.URL.Path == "/admin/api/2020-10/draft_orders/count.json" && req.URL.RawQuery == "status=invoice_sent&updated_at_min=2020-10-01T00%3A00%3A00Z":
+			_, _ = rw.Write([]byte(`{"count":3}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	draftOrder, err := client.DraftOrderCreate(requestContext, DraftOrder{
+		LineItems: []DraftOrderLineItem{
+			{VariantId: 447654529, Quantity: 1},
+			{Title: "Setup service", Price: "20.00", Quantity: 2, Taxable: Bool(false), RequiresShipping: Bool(false)},
+		},
+		TaxExempt:       Bool(false),
+		AppliedDiscount: &AppliedDiscount{Title: "Wholesale", Value: "10.0", ValueType: DiscountValueTypePercentage},
+		ShippingLine:    &DraftOrderShippingLine{Custom: true, Title: "Freight", Price: "10.00"},
+	})
+	if err != nil || draftOrder.Id != 994118539 || draftOrder.Status != DraftOrderStatusOpen {
+		t.Fatalf("unexpected draft order %+v %v", draftOrder, err)
+	}
+
+	invoice, err := client.DraftOrderSendInvoice(requestContext, draftOrder.Id, DraftOrderInvoice{Subject: "Your quote", CustomMessage: "Valid for 30 days"})
+	if err != nil || invoice.Subject != "Your quote" || invoice.CustomMessage != "Valid for 30 days" {
+		t.Errorf("unexpected invoice %+v %v", invoice, err)
+	}
+
+	draftOrder, err = client.DraftOrderComplete(requestContext, draftOrder.Id, true)
+	if err != nil || draftOrder.OrderId != 450789469 || draftOrder.Status != DraftOrderStatusCompleted {
+		t.Errorf("unexpected completed draft order %+v %v", draftOrder, err)
+	}
+
+	count, err := client.DraftOrderCount(requestContext, DraftOrderRequestOptions{Status: DraftOrderStatusInvoiceSent, UpdatedAtMin: "2020-10-01T00:00:00Z"})
+	if err != nil || count != 3 {
+		t.Errorf("unexpected count %v %v", count, err)
+	}
+}
@@ -0,0 +1,172 @@
+package shopify
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const XRequestId = "X-Request-Id"
+
+var (
+	ErrNotFound     = errors.New("shopify: not found")
+	ErrRateLimited  = errors.New("shopify: rate limited")
+	ErrUnauthorized = errors.New("shopify: unauthorized")
+	ErrForbidden    = errors.New("shopify: forbidden")
+	ErrValidation   = errors.New("shopify: validation failed")
+)
+
+/*
+ShopifyError is returned for every response with a status code of 300 or above.
+Shopify sends the "errors" key either as a plain string, a list of strings or an object
+keyed by field name, the first two end up in Message and the last one in FieldErrors.
+*/
+type ShopifyError struct {
+	StatusCode  int
+	Message     string
+	FieldErrors map[string][]string
+	RequestId   string
+	Method      string
+	Url         string
+	Body        []byte
+}
+
+func (e *ShopifyError) Error() string {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("shopify: %v %v received %d", e.Method, e.Url, e.StatusCode))
+	if e.RequestId != "" {
+		builder.WriteString(" (request id " + e.RequestId + ")")
+	}
+
+	if e.Message != "" {
+		builder.WriteString(": " + e.Message)
+	}
+
+	fields := make([]string, 0, len(e.FieldErrors))
+	for field := range e.FieldErrors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		builder.WriteString(fmt.Sprintf(": %v %v", field, strings.Join(e.FieldErrors[field], ", ")))
+	}
+
+	return builder.String()
+}
+
+/*
+Allows errors.Is to match the error against the ErrNotFound, ErrRateLimited, ErrUnauthorized,
+ErrForbidden and ErrValidation sentinels.
+*/
+func (e *ShopifyError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrValidation:
+		return e.StatusCode == http.StatusUnprocessableEntity
+	}
+
+	return false
+}
+
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+func IsValidationError(err error) bool {
+	return errors.Is(err, ErrValidation)
+}
+
+/*
+Builds a ShopifyError from a failed response and its already read body.
+*/
+func newShopifyError(request Request, resp *http.Response, body []byte) *ShopifyError {
+	shopifyErr := &ShopifyError{
+		StatusCode: resp.StatusCode,
+		RequestId:  resp.Header.Get(XRequestId),
+		Method:     request.Method,
+		Url:        request.Url,
+		Body:       body,
+	}
+
+	var payload struct {
+		Errors json.RawMessage `json:"errors"`
+		Error  string          `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		shopifyErr.Message = strings.TrimSpace(string(body))
+		return shopifyErr
+	}
+
+	shopifyErr.Message, shopifyErr.FieldErrors = parseErrorsPayload(payload.Errors)
+	if shopifyErr.Message == "" && shopifyErr.FieldErrors == nil {
+		shopifyErr.Message = payload.Error
+	}
+	if shopifyErr.Message == "" && shopifyErr.FieldErrors == nil {
+		shopifyErr.Message = http.StatusText(resp.StatusCode)
+	}
+
+	return shopifyErr
+}
+
+func parseErrorsPayload(raw json.RawMessage) (message string, fieldErrors map[string][]string) {
+	if len(raw) == 0 {
+		return
+	}
+
+	if err := json.Unmarshal(raw, &message); err == nil {
+		return
+	}
+
+	var messages []string
+	if err := json.Unmarshal(raw, &messages); err == nil {
+		message = strings.Join(messages, "; ")
+		return
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		message = string(raw)
+		return
+	}
+
+	fieldErrors = make(map[string][]string, len(fields))
+	for field, value := range fields {
+		var single string
+		if err := json.Unmarshal(value, &single); err == nil {
+			fieldErrors[field] = []string{single}
+			continue
+		}
+
+		var list []string
+		if err := json.Unmarshal(value, &list); err == nil {
+			fieldErrors[field] = list
+			continue
+		}
+
+		fieldErrors[field] = []string{string(value)}
+	}
+
+	return
+}
@@ -0,0 +1,71 @@
+package shopify
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+)
+
+func TestShopifyErrorFromResponse(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set(XRequestId, "abc-123")
+		if req.Method == "POST" {
+			rw.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = rw.Write([]byte(`{"errors":{"address":["can't be blank"],"topic":"Invalid topic specified."}}`))
+			return
+		}
+
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = rw.Write([]byte(`{"errors":"Not Found"}`))
+	}))
+	defer server.Close()
+
+	client := RestAdminClient{
+		Http:    server.Client(),
+		Logger:  log.New(os.Stdout, "", log.Ldate|log.Ltime|log.Lshortfile),
+		Version: VERSION_2019_07,
+	}
+
+	serverUrl, _ := url.Parse(server.URL)
+	requestContext := Ctx{
+		AccessToken: "thisisatoken",
+		ShopName:    serverUrl.Host,
+		Ctx:         context.Background(),
+	}
+
+	_, err := client.ShopGet(requestContext)
+	if !IsNotFound(err) || IsRateLimited(err) || IsValidationError(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	var shopifyErr *ShopifyError
+	if !errors.As(err, &shopifyErr) {
+		t.Fatalf("expected a ShopifyError, got %T", err)
+	}
+	if shopifyErr.Message != "Not Found" || shopifyErr.RequestId != "abc-123" || shopifyErr.Url != server.URL+"/admin/api/2019-07/shop.json" {
+		t.Errorf("unexpected error contents %+v", shopifyErr)
+	}
+
+	_, err = client.WebhookCreate(requestContext, Webhook{Topic: "bad"})
+	if !IsValidationError(err) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+	if !errors.As(err, &shopifyErr) {
+		t.Fatalf("expected a ShopifyError, got %T", err)
+	}
+	if shopifyErr.FieldErrors["address"][0] != "can't be blank" || shopifyErr.FieldErrors["topic"][0] != "Invalid topic specified." {
+		t.Errorf("unexpected field errors %v", shopifyErr.FieldErrors)
+	}
+}
+
+func TestParseErrorsPayload(t *testing.T) {
+	message, fields := parseErrorsPayload([]byte(`["first","second"]`))
+	if message != "first; second" || fields != nil {
+		t.Errorf("unexpected parse of a list of errors %v %v", message, fields)
+	}
+}
@@ -0,0 +1,280 @@
+package shopify
+
+import (
+	"encoding/json"
+	"github.com/google/go-querystring/query"
+	"github.com/pkg/errors"
+	"strconv"
+)
+
+const (
+	FulfillmentEventLabelPrinted      = "label_printed"
+	FulfillmentEventLabelPurchased    = "label_purchased"
+	FulfillmentEventAttemptedDelivery = "attempted_delivery"
+	FulfillmentEventReadyForPickup    = "ready_for_pickup"
+	FulfillmentEventConfirmed         = "confirmed"
+	FulfillmentEventInTransit         = "in_transit"
+	FulfillmentEventOutForDelivery    = "out_for_delivery"
+	FulfillmentEventDelivered         = "delivered"
+	FulfillmentEventFailure           = "failure"
+)
+
+type Fulfillment struct {
+	CreatedAt       string     `json:"created_at,omitempty"`
+	Id              int        `json:"id,omitempty"`
+	LineItems       []LineItem `json:"line_items,omitempty"`
+	LocationId      int        `json:"location_id,omitempty"`
+	Name            string     `json:"name,omitempty"`
+	NotifyCustomer  bool       `json:"notify_customer,omitempty"`
+	OrderId         int        `json:"order_id,omitempty"`
+	Service         string     `json:"service,omitempty"`
+	ShipmentStatus  string     `json:"shipment_status,omitempty"`
+	Status          string     `json:"status,omitempty"`
+	TrackingCompany string     `json:"tracking_company,omitempty"`
+	TrackingNumber  string     `json:"tracking_number,omitempty"`
+	TrackingNumbers []string   `json:"tracking_numbers,omitempty"`
+	TrackingUrl     string     `json:"tracking_url,omitempty"`
+	TrackingUrls    []string   `json:"tracking_urls,omitempty"`
+	UpdatedAt       string     `json:"updated_at,omitempty"`
+}
+
+type TrackingInfo struct {
+	Company string `json:"company,omitempty"`
+	Number  string `json:"number,omitempty"`
+	Url     string `json:"url,omitempty"`
+}
+
+/*
+The line items of a fulfillment order to fulfill, leave FulfillmentOrderLineItems empty to fulfill all of them.
+*/
+type FulfillmentOrderLineItems struct {
+	FulfillmentOrderId        int                        `json:"fulfillment_order_id"`
+	FulfillmentOrderLineItems []FulfillmentOrderLineItem `json:"fulfillment_order_line_items,omitempty"`
+}
+
+/*
+Creates a fulfillment for line items of one or more fulfillment orders, which have to be at the same location.
+*/
+type FulfillmentRequest struct {
+	LineItemsByFulfillmentOrder []FulfillmentOrderLineItems `json:"line_items_by_fulfillment_order"`
+	Message                     string                      `json:"message,omitempty"`
+	NotifyCustomer              bool                        `json:"notify_customer,omitempty"`
+	OriginAddress               *Address                    `json:"origin_address,omitempty"`
+	TrackingInfo                *TrackingInfo               `json:"tracking_info,omitempty"`
+}
+
+type FulfillmentWrapper struct {
+	Fulfillment *Fulfillment `json:"fulfillment"`
+}
+
+func (f FulfillmentWrapper) GetResourceName() string {
+	return orderPath(f.Fulfillment.OrderId) + "/fulfillments"
+}
+
+func (f FulfillmentWrapper) GetId() int {
+	return f.Fulfillment.Id
+}
+
+func (f FulfillmentWrapper) BuildGetUrl(request Request) string {
+	return BuildIdUrl(request, f.GetResourceName(), f.GetId())
+}
+
+type Fulfillments struct {
+	Fulfillments []Fulfillment `json:"fulfillments"`
+}
+
+type FulfillmentsWrapper struct {
+	OrderId      int
+	Fulfillments []Fulfillment
+}
+
+func (f *FulfillmentsWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper Fulfillments
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return
+	}
+
+	f.Fulfillments = append(f.Fulfillments, wrapper.Fulfillments...)
+	return
+}
+
+func (f FulfillmentsWrapper) GetResourceName() string {
+	return orderPath(f.OrderId) + "/fulfillments"
+}
+
+type FulfillmentRequestOptions struct {
+	CreatedAtMin string   `url:"created_at_min,omitempty"`
+	CreatedAtMax string   `url:"created_at_max,omitempty"`
+	Fields       []string `url:"fields,omitempty,comma"`
+	Limit        int      `url:"limit,omitempty"`
+	SinceId      int      `url:"since_id,omitempty"`
+	UpdatedAtMin string   `url:"updated_at_min,omitempty"`
+	UpdatedAtMax string   `url:"updated_at_max,omitempty"`
+}
+
+func (f FulfillmentRequestOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(f)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", f)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
+}
+
+func (r *RestAdminClient) FulfillmentList(context Ctx, orderId int, options FulfillmentRequestOptions) (results []Fulfillment, next string, err error) {
+	var wrapper = &FulfillmentsWrapper{OrderId: orderId}
+	next, err = r.List(context, options, wrapper)
+	results = wrapper.Fulfillments
+	return
+}
+
+type FulfillmentIterator struct {
+	*Iterator
+}
+
+/*
+The fulfillments on the current page.
+*/
+func (i *FulfillmentIterator) Fulfillments() []Fulfillment {
+	if i.Page() == nil {
+		return nil
+	}
+
+	return i.Page().(*FulfillmentsWrapper).Fulfillments
+}
+
+func (r *RestAdminClient) FulfillmentListIterator(context Ctx, orderId int, options FulfillmentRequestOptions) *FulfillmentIterator {
+	return &FulfillmentIterator{r.NewIterator(context, options, func() Lister { return &FulfillmentsWrapper{OrderId: orderId} })}
+}
+
+func (r *RestAdminClient) FulfillmentGet(context Ctx, orderId int, id int) (result *Fulfillment, err error) {
+	wrapper := &FulfillmentWrapper{Fulfillment: &Fulfillment{OrderId: orderId, Id: id}}
+	err = r.Get(context, wrapper)
+	result = wrapper.Fulfillment
+
+	return
+}
+
+func (r *RestAdminClient) FulfillmentCount(context Ctx, orderId int, options FulfillmentRequestOptions) (count int, err error) {
+	return r.Count(context, orderPath(orderId)+"/fulfillments", options)
+}
+
+/*
+Fulfills the line items of the fulfillment orders, the customer gets the shipping confirmation
+email when NotifyCustomer is set.
+*/
+func (r *RestAdminClient) FulfillmentCreate(context Ctx, request FulfillmentRequest) (result *Fulfillment, err error) {
+	if len(request.LineItemsByFulfillmentOrder) == 0 {
+		err = errors.New("a fulfillment needs the line items of at least one fulfillment order")
+		return
+	}
+
+	body := struct {
+		Fulfillment FulfillmentRequest `json:"fulfillment"`
+	}{request}
+
+	var wrapper FulfillmentWrapper
+	err = r.Send(context, "POST", "fulfillments", nil, body, &wrapper)
+	result = wrapper.Fulfillment
+
+	return
+}
+
+/*
+Replaces the tracking information of the fulfillment, the customer is emailed the new details when notifyCustomer is set.
+*/
+func (r *RestAdminClient) FulfillmentUpdateTracking(context Ctx, id int, trackingInfo TrackingInfo, notifyCustomer bool) (result *Fulfillment, err error) {
+	var body struct {
+		Fulfillment struct {
+			NotifyCustomer bool         `json:"notify_customer"`
+			TrackingInfo   TrackingInfo `json:"tracking_info"`
+		} `json:"fulfillment"`
+	}
+	body.Fulfillment.NotifyCustomer = notifyCustomer
+	body.Fulfillment.TrackingInfo = trackingInfo
+
+	var wrapper FulfillmentWrapper
+	err = r.Send(context, "POST", fulfillmentPath(id)+"/update_tracking", nil, body, &wrapper)
+	result = wrapper.Fulfillment
+
+	return
+}
+
+func (r *RestAdminClient) FulfillmentCancel(context Ctx, id int) (result *Fulfillment, err error) {
+	var wrapper FulfillmentWrapper
+	err = r.Send(context, "POST", fulfillmentPath(id)+"/cancel", nil, struct{}{}, &wrapper)
+	result = wrapper.Fulfillment
+
+	return
+}
+
+/*
+A tracking update of a fulfillment, Status is one of the FulfillmentEvent values.
+*/
+type FulfillmentEvent struct {
+	Address1            string  `json:"address1,omitempty"`
+	City                string  `json:"city,omitempty"`
+	Country             string  `json:"country,omitempty"`
+	CreatedAt           string  `json:"created_at,omitempty"`
+	EstimatedDeliveryAt string  `json:"estimated_delivery_at,omitempty"`
+	FulfillmentId       int     `json:"fulfillment_id,omitempty"`
+	HappenedAt          string  `json:"happened_at,omitempty"`
+	Id                  int     `json:"id,omitempty"`
+	Latitude            float64 `json:"latitude,omitempty"`
+	Longitude           float64 `json:"longitude,omitempty"`
+	Message             string  `json:"message,omitempty"`
+	OrderId             int     `json:"order_id,omitempty"`
+	Province            string  `json:"province,omitempty"`
+	ShopId              int     `json:"shop_id,omitempty"`
+	Status              string  `json:"status,omitempty"`
+	UpdatedAt           string  `json:"updated_at,omitempty"`
+	Zip                 string  `json:"zip,omitempty"`
+}
+
+type fulfillmentEventWrapper struct {
+	FulfillmentEvent *FulfillmentEvent `json:"fulfillment_event"`
+}
+
+func (r *RestAdminClient) FulfillmentEventList(context Ctx, orderId int, fulfillmentId int) (results []FulfillmentEvent, err error) {
+	var wrapper struct {
+		FulfillmentEvents []FulfillmentEvent `json:"fulfillment_events"`
+	}
+	err = r.Send(context, "GET", fulfillmentEventsPath(orderId, fulfillmentId), nil, nil, &wrapper)
+	results = wrapper.FulfillmentEvents
+
+	return
+}
+
+func (r *RestAdminClient) FulfillmentEventGet(context Ctx, orderId int, fulfillmentId int, id int) (result *FulfillmentEvent, err error) {
+	var wrapper fulfillmentEventWrapper
+	err = r.Send(context, "GET", fulfillmentEventsPath(orderId, fulfillmentId)+"/"+strconv.Itoa(id), nil, nil, &wrapper)
+	result = wrapper.FulfillmentEvent
+
+	return
+}
+
+func (r *RestAdminClient) FulfillmentEventCreate(context Ctx, orderId int, fulfillmentId int, request FulfillmentEvent) (result *FulfillmentEvent, err error) {
+	body := struct {
+		Event FulfillmentEvent `json:"event"`
+	}{request}
+
+	var wrapper fulfillmentEventWrapper
+	err = r.Send(context, "POST", fulfillmentEventsPath(orderId, fulfillmentId), nil, body, &wrapper)
+	result = wrapper.FulfillmentEvent
+
+	return
+}
+
+func (r *RestAdminClient) FulfillmentEventDelete(context Ctx, orderId int, fulfillmentId int, id int) (err error) {
+	return r.Delete(context, fulfillmentEventsPath(orderId, fulfillmentId), id)
+}
+
+func fulfillmentPath(id int) string {
+	return "fulfillments/" + strconv.Itoa(id)
+}
+
+func fulfillmentEventsPath(orderId int, fulfillmentId int) string {
+	return orderPath(orderId) + "/fulfillments/" + strconv.Itoa(fulfillmentId) + "/events"
+}
@@ -0,0 +1,156 @@
+package shopify
+
+import (
+	"strconv"
+)
+
+const (
+	FulfillmentHoldAwaitingPayment     = "awaiting_payment"
+	FulfillmentHoldHighRiskOfFraud     = "high_risk_of_fraud"
+	FulfillmentHoldIncorrectAddress    = "incorrect_address"
+	FulfillmentHoldInventoryOutOfStock = "inventory_out_of_stock"
+	FulfillmentHoldOther               = "other"
+)
+
+/*
+A group of an order's line items that are fulfilled from one location. Shopify creates them when
+the order is placed, SupportedActions lists what can be done with it in its current status.
+*/
+type FulfillmentOrder struct {
+	AssignedLocation   *FulfillmentOrderLocation  `json:"assigned_location,omitempty"`
+	AssignedLocationId int                        `json:"assigned_location_id,omitempty"`
+	Destination        *Address                   `json:"destination,omitempty"`
+	FulfillAt          string                     `json:"fulfill_at,omitempty"`
+	FulfillmentHolds   []FulfillmentHold          `json:"fulfillment_holds,omitempty"`
+	Id                 int                        `json:"id,omitempty"`
+	LineItems          []FulfillmentOrderLineItem `json:"line_items,omitempty"`
+	OrderId            int                        `json:"order_id,omitempty"`
+	RequestStatus      string                     `json:"request_status,omitempty"`
+	ShopId             int                        `json:"shop_id,omitempty"`
+	Status             string                     `json:"status,omitempty"`
+	SupportedActions   []string                   `json:"supported_actions,omitempty"`
+}
+
+type FulfillmentOrderLocation struct {
+	Address
+	LocationId int `json:"location_id,omitempty"`
+}
+
+type FulfillmentOrderLineItem struct {
+	FulfillableQuantity int `json:"fulfillable_quantity,omitempty"`
+	FulfillmentOrderId  int `json:"fulfillment_order_id,omitempty"`
+	Id                  int `json:"id,omitempty"`
+	InventoryItemId     int `json:"inventory_item_id,omitempty"`
+	LineItemId          int `json:"line_item_id,omitempty"`
+	Quantity            int `json:"quantity,omitempty"`
+	ShopId              int `json:"shop_id,omitempty"`
+	VariantId           int `json:"variant_id,omitempty"`
+}
+
+/*
+Holds a fulfillment order back from being fulfilled, Reason is one of the FulfillmentHold values.
+*/
+type FulfillmentHold struct {
+	NotifyMerchant bool   `json:"notify_merchant,omitempty"`
+	Reason         string `json:"reason,omitempty"`
+	ReasonNotes    string `json:"reason_notes,omitempty"`
+}
+
+type FulfillmentOrderWrapper struct {
+	FulfillmentOrder *FulfillmentOrder `json:"fulfillment_order"`
+}
+
+/*
+The result of moving a fulfillment order. The original is closed when all of its line items moved,
+otherwise RemainingFulfillmentOrder holds the line items left at the original location.
+*/
+type FulfillmentOrderMove struct {
+	OriginalFulfillmentOrder  *FulfillmentOrder `json:"original_fulfillment_order"`
+	MovedFulfillmentOrder     *FulfillmentOrder `json:"moved_fulfillment_order"`
+	RemainingFulfillmentOrder *FulfillmentOrder `json:"remaining_fulfillment_order"`
+}
+
+/*
+The result of cancelling a fulfillment order, the replacement holds the line items again so they
+can be fulfilled some other way.
+*/
+type FulfillmentOrderCancellation struct {
+	FulfillmentOrder            *FulfillmentOrder `json:"fulfillment_order"`
+	ReplacementFulfillmentOrder *FulfillmentOrder `json:"replacement_fulfillment_order"`
+}
+
+/*
+Lists every fulfillment order of the order, the endpoint isn't paginated.
+*/
+func (r *RestAdminClient) FulfillmentOrderList(context Ctx, orderId int) (results []FulfillmentOrder, err error) {
+	var wrapper struct {
+		FulfillmentOrders []FulfillmentOrder `json:"fulfillment_orders"`
+	}
+	err = r.Send(context, "GET", orderPath(orderId)+"/fulfillment_orders", nil, nil, &wrapper)
+	results = wrapper.FulfillmentOrders
+
+	return
+}
+
+func (r *RestAdminClient) FulfillmentOrderGet(context Ctx, id int) (result *FulfillmentOrder, err error) {
+	var wrapper FulfillmentOrderWrapper
+	err = r.Send(context, "GET", fulfillmentOrderPath(id), nil, nil, &wrapper)
+	result = wrapper.FulfillmentOrder
+
+	return
+}
+
+/*
+Moves the fulfillment order to another location. Without line items all of them are moved, otherwise
+only the given quantities of the listed line items move, identified by their Id, and the rest stay
+behind in the RemainingFulfillmentOrder.
+*/
+func (r *RestAdminClient) FulfillmentOrderMove(context Ctx, id int, newLocationId int, lineItems ...FulfillmentOrderLineItem) (result *FulfillmentOrderMove, err error) {
+	var body struct {
+		FulfillmentOrder struct {
+			NewLocationId             int                        `json:"new_location_id"`
+			FulfillmentOrderLineItems []FulfillmentOrderLineItem `json:"fulfillment_order_line_items,omitempty"`
+		} `json:"fulfillment_order"`
+	}
+	body.FulfillmentOrder.NewLocationId = newLocationId
+	for _, lineItem := range lineItems {
+		body.FulfillmentOrder.FulfillmentOrderLineItems = append(body.FulfillmentOrder.FulfillmentOrderLineItems,
+			FulfillmentOrderLineItem{Id: lineItem.Id, Quantity: lineItem.Quantity})
+	}
+
+	result = new(FulfillmentOrderMove)
+	err = r.Send(context, "POST", fulfillmentOrderPath(id)+"/move", nil, body, result)
+
+	return
+}
+
+func (r *RestAdminClient) FulfillmentOrderCancel(context Ctx, id int) (result *FulfillmentOrderCancellation, err error) {
+	result = new(FulfillmentOrderCancellation)
+	err = r.Send(context, "POST", fulfillmentOrderPath(id)+"/cancel", nil, struct{}{}, result)
+
+	return
+}
+
+func (r *RestAdminClient) FulfillmentOrderHold(context Ctx, id int, hold FulfillmentHold) (result *FulfillmentOrder, err error) {
+	body := struct {
+		FulfillmentHold FulfillmentHold `json:"fulfillment_hold"`
+	}{hold}
+
+	var wrapper FulfillmentOrderWrapper
+	err = r.Send(context, "POST", fulfillmentOrderPath(id)+"/hold", nil, body, &wrapper)
+	result = wrapper.FulfillmentOrder
+
+	return
+}
+
+func (r *RestAdminClient) FulfillmentOrderReleaseHold(context Ctx, id int) (result *FulfillmentOrder, err error) {
+	var wrapper FulfillmentOrderWrapper
+	err = r.Send(context, "POST", fulfillmentOrderPath(id)+"/release_hold", nil, struct{}{}, &wrapper)
+	result = wrapper.FulfillmentOrder
+
+	return
+}
+
+func fulfillmentOrderPath(id int) string {
+	return "fulfillment_orders/" + strconv.Itoa(id)
+}
@@ -0,0 +1,160 @@
+package shopify
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestFulfillmentCreateFromFulfillmentOrders(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/admin/api/2020-10/orders/450789469/fulfillment_orders.json":
+			_, _ = rw.Write([]byte(`{"fulfillment_orders":[{"id":1046000778,"order_id":450789469,"status":"open","assigned_location_id":24826418,
+				"supported_actions":["create_fulfillment","move"],"line_items":[{"id":1025578633,"line_item_id":466157049,"quantity":1,"fulfillable_quantity":1}]}]}`))
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/fulfillments.json":
+			var body struct {
+				Fulfillment FulfillmentRequest `json:"fulfillment"`
+			}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			if !body.Fulfillment.NotifyCustomer || body.Fulfillment.TrackingInfo.Number != "1Z001985YW99744790" ||
+				body.Fulfillment.LineItemsByFulfillmentOrder[0].FulfillmentOrderId != 1046000778 {
+				rw.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			rw.WriteHeader(http.StatusCreated)
+			_, _ = rw.Write([]byte(`{"fulfillment":{"id":255858046,"order_id":450789469,"status":"success","tracking_number":"1Z001985YW99744790","tracking_company":"UPS"}}`))
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/fulfillment_orders/1046000778/hold.json":
+			var body map[string]FulfillmentHold
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			if body["fulfillment_hold"].Reason != FulfillmentHoldInventoryOutOfStock {
+				rw.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			_, _ = rw.Write([]byte(`{"fulfillment_order":{"id":1046000778,"status":"on_hold","fulfillment_holds":[{"reason":"inventory_out_of_stock"}]}}`))
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/orders/450789469/fulfillments/255858046/events.json":
+			_, _ = rw.Write([]byte(`{"fulfillment_event":{"id":944956391,"fulfillment_id":255858046,"status":"in_transit"}}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	fulfillmentOrders, err := client.FulfillmentOrderList(requestContext, 450789469)
+	if err != nil || len(fulfillmentOrders) != 1 || fulfillmentOrders[0].LineItems[0].FulfillableQuantity != 1 {
+		t.Fatalf("unexpected fulfillment orders %+v %v", fulfillmentOrders, err)
+	}
+
+	fulfillment, err := client.FulfillmentCreate(requestContext, FulfillmentRequest{
+		LineItemsByFulfillmentOrder: []FulfillmentOrderLineItems{{FulfillmentOrderId: fulfillmentOrders[0].Id}},
+		NotifyCustomer:              true,
+		TrackingInfo:                &TrackingInfo{Company: "UPS", Number: "1Z001985YW99744790"},
+	})
+	if err != nil || fulfillment.Id != 255858046 || fulfillment.TrackingCompany != "UPS" {
+		t.Fatalf("unexpected fulfillment %+v %v", fulfillment, err)
+	}
+
+	event, err := client.FulfillmentEventCreate(requestContext, 450789469, fulfillment.Id, FulfillmentEvent{Status: FulfillmentEventInTransit})
+	if err != nil || event.Id != 944956391 {
+		t.Errorf("unexpected event %+v %v", event, err)
+	}
+
+	fulfillmentOrder, err := client.FulfillmentOrderHold(requestContext, 1046000778, FulfillmentHold{Reason: FulfillmentHoldInventoryOutOfStock})
+	if err != nil || fulfillmentOrder.Status != "on_hold" {
+		t.Errorf("unexpected fulfillment order %+v %v", fulfillmentOrder, err)
+	}
+}
+
+func TestFulfillmentOrderMove(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != "POST" || req.URL.Path != "/admin/api/2020-10/fulfillment_orders/1046000778/move.json" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var raw map[string]map[string]json.RawMessage
+		_ = json.NewDecoder(req.Body).Decode(&raw)
+		if len(raw["fulfillment_order"]) != 2 || string(raw["fulfillment_order"]["new_location_id"]) != "905684977" ||
+			string(raw["fulfillment_order"]["fulfillment_order_line_items"]) != `[{"id":1025578633,"quantity":1}]` {
+			rw.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`{"original_fulfillment_order":{"id":1046000778,"status":"open"},"moved_fulfillment_order":{"id":1046000779,
+			"assigned_location_id":905684977},"remaining_fulfillment_order":{"id":1046000778,"assigned_location_id":24826418}}`))
+	})
+	defer server.Close()
+
+	move, err := client.FulfillmentOrderMove(requestContext, 1046000778, 905684977,
+		FulfillmentOrderLineItem{Id: 1025578633, Quantity: 1, LineItemId: 466157049, FulfillableQuantity: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if move.MovedFulfillmentOrder.AssignedLocationId != 905684977 || move.RemainingFulfillmentOrder == nil {
+		t.Errorf("unexpected move %+v", move)
+	}
+}
+
+func TestFulfillmentOrderCancelAndReleaseHold(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		if req.Method != "POST" || string(body) != "{}" {
+			rw.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+
+		switch req.URL.Path {
+		case "/admin/api/2020-10/fulfillment_orders/1046000778/cancel.json":
+			_, _ = rw.Write([]byte(`{"fulfillment_order":{"id":1046000778,"status":"closed"},"replacement_fulfillment_order":{"id":1046000780,"status":"open"}}`))
+		case "/admin/api/2020-10/fulfillment_orders/1046000778/release_hold.json":
+			_, _ = rw.Write([]byte(`{"fulfillment_order":{"id":1046000778,"status":"open"}}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	cancellation, err := client.FulfillmentOrderCancel(requestContext, 1046000778)
+	if err != nil || cancellation.FulfillmentOrder.Status != "closed" || cancellation.ReplacementFulfillmentOrder.Id != 1046000780 {
+		t.Errorf("unexpected cancellation %+v %v", cancellation, err)
+	}
+
+	fulfillmentOrder, err := client.FulfillmentOrderReleaseHold(requestContext, 1046000778)
+	if err != nil || fulfillmentOrder.Status != "open" {
+		t.Errorf("unexpected fulfillment order %+v %v", fulfillmentOrder, err)
+	}
+}
+
+func TestFulfillmentUpdateTrackingAndCancel(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		switch {
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/fulfillments/255858046/update_tracking.json":
+			if string(body) != `{"fulfillment":{"notify_customer":false,"tracking_info":{"company":"UPS","number":"1Z001985YW99744790"}}}` {
+				rw.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			_, _ = rw.Write([]byte(`{"fulfillment":{"id":255858046,"status":"success","tracking_number":"1Z001985YW99744790","tracking_company":"UPS"}}`))
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/fulfillments/255858046/cancel.json":
+			if string(body) != "{}" {
+				rw.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			_, _ = rw.Write([]byte(`{"fulfillment":{"id":255858046,"status":"cancelled"}}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	fulfillment, err := client.FulfillmentUpdateTracking(requestContext, 255858046, TrackingInfo{Company: "UPS", Number: "1Z001985YW99744790"}, false)
+	if err != nil || fulfillment.TrackingNumber != "1Z001985YW99744790" {
+		t.Errorf("unexpected fulfillment %+v %v", fulfillment, err)
+	}
+
+	fulfillment, err = client.FulfillmentCancel(requestContext, 255858046)
+	if err != nil || fulfillment.Status != "cancelled" {
+		t.Errorf("unexpected fulfillment %+v %v", fulfillment, err)
+	}
+}
@@ -0,0 +1,324 @@
+package shopify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const graphQLThrottledCode = "THROTTLED"
+
+/*
+GraphQLClient sends queries to the GraphQL Admin API using the transport, api version and retry
+policy of the RestAdminClient. Query costs are tracked per shop from the extensions.cost of every
+response, a query waits until the shop's bucket has restored enough points for the cost of the
+previous query, and throttled queries are retried once the points are back.
+
+The REST rate limiter isn't used, GraphQL has its own cost based bucket.
+*/
+type GraphQLClient struct {
+	Rest *RestAdminClient
+	// The first wait between bulk operation polls, it doubles up to 30s. Defaults to 1s.
+	BulkPollInterval time.Duration
+
+	mu      sync.Mutex
+	buckets map[string]*graphQLBucket
+}
+
+type graphQLBucket struct {
+	status    GraphQLThrottleStatus
+	requested float64
+	updated   time.Time
+}
+
+func NewGraphQLClient(rest *RestAdminClient) *GraphQLClient {
+	return &GraphQLClient{
+		Rest:    rest,
+		buckets: make(map[string]*graphQLBucket),
+	}
+}
+
+type GraphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+/*
+A top level error of a GraphQL response, for example a syntax error or a throttled query.
+*/
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Locations  []GraphQLLocation      `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+/*
+The extensions.code of the error, THROTTLED when the query cost more than the shop had available.
+*/
+func (e GraphQLError) Code() string {
+	code, _ := e.Extensions["code"].(string)
+	return code
+}
+
+/*
+The top level errors of a GraphQL response. errors.Is matches ErrRateLimited when the query was throttled.
+*/
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, graphQLError := range e {
+		messages = append(messages, graphQLError.Message)
+	}
+
+	return "shopify: graphql: " + strings.Join(messages, "; ")
+}
+
+func (e GraphQLErrors) Is(target error) bool {
+	return target == ErrRateLimited && e.throttled()
+}
+
+func (e GraphQLErrors) throttled() bool {
+	for _, graphQLError := range e {
+		if graphQLError.Code() == graphQLThrottledCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+A validation error from a mutation's userErrors. Mutation is the top level field of the response it
+was found under, for example productCreate.
+*/
+type GraphQLUserError struct {
+	Mutation string   `json:"-"`
+	Field    []string `json:"field"`
+	Message  string   `json:"message"`
+	Code     string   `json:"code,omitempty"`
+}
+
+/*
+The userErrors of the mutations in a response. The data is still decoded when they are returned.
+errors.Is matches ErrValidation.
+*/
+type GraphQLUserErrors []GraphQLUserError
+
+func (e GraphQLUserErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, userError := range e {
+		message := userError.Mutation + ": " + userError.Message
+		if len(userError.Field) > 0 {
+			message = fmt.Sprintf("%v: %v %v", userError.Mutation, strings.Join(userError.Field, "."), userError.Message)
+		}
+		messages = append(messages, message)
+	}
+
+	return "shopify: graphql user errors: " + strings.Join(messages, "; ")
+}
+
+func (e GraphQLUserErrors) Is(target error) bool {
+	return target == ErrValidation
+}
+
+type GraphQLThrottleStatus struct {
+	MaximumAvailable   float64 `json:"maximumAvailable"`
+	CurrentlyAvailable float64 `json:"currentlyAvailable"`
+	RestoreRate        float64 `json:"restoreRate"`
+}
+
+type GraphQLCost struct {
+	RequestedQueryCost float64               `json:"requestedQueryCost"`
+	ActualQueryCost    *float64              `json:"actualQueryCost"`
+	ThrottleStatus     GraphQLThrottleStatus `json:"throttleStatus"`
+}
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type graphQLResponse struct {
+	Data       json.RawMessage `json:"data"`
+	Errors     GraphQLErrors   `json:"errors"`
+	Extensions struct {
+		Cost *GraphQLCost `json:"cost"`
+	} `json:"extensions"`
+}
+
+/*
+Runs the query, or mutation, with the variables and decodes the data into result, which may be nil.
+Top level errors are returned as GraphQLErrors and the userErrors of mutations as GraphQLUserErrors.
+*/
+func (g *GraphQLClient) Query(context Ctx, query string, variables map[string]interface{}, result interface{}) (err error) {
+	body, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		err = errors.WithMessage(err, "failure while marshaling the graphql request")
+		return
+	}
+
+	var response graphQLResponse
+	for attempt := 1; ; attempt++ {
+		if err = sleepContext(context.Ctx, g.delay(context.ShopName)); err != nil {
+			err = errors.WithMessage(err, "cancelled while waiting for the query cost to be restored")
+			return
+		}
+
+		response, err = g.send(context, body)
+		if err != nil {
+			return
+		}
+
+		g.update(context.ShopName, response.Extensions.Cost)
+		if !response.Errors.throttled() || attempt > maxRateLimitRetries {
+			break
+		}
+
+		if response.Extensions.Cost == nil {
+			if err = sleepContext(context.Ctx, defaultRetryAfter); err != nil {
+				return
+			}
+		}
+	}
+
+	data := bytes.TrimSpace(response.Data)
+	if result != nil && len(data) > 0 && !bytes.Equal(data, []byte("null")) {
+		if err = json.Unmarshal(data, result); err != nil {
+			err = errors.WithMessage(err, "error unmarshaling the graphql data")
+			return
+		}
+	}
+
+	if len(response.Errors) > 0 {
+		err = response.Errors
+		return
+	}
+
+	if userErrors := findUserErrors(data); len(userErrors) > 0 {
+		err = userErrors
+	}
+
+	return
+}
+
+/*
+The throttle status of the shop from its last response.
+*/
+func (g *GraphQLClient) ThrottleStatus(shopName string) (status GraphQLThrottleStatus, ok bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	b, ok := g.buckets[shopName]
+	if ok {
+		status = b.status
+	}
+	return
+}
+
+func (g *GraphQLClient) send(context Ctx, body []byte) (response graphQLResponse, err error) {
+	// the REST limiter doesn't know about query costs, pacing is done by the GraphQLClient
+	rest := *g.Rest
+	rest.Limiter = nil
+
+	var request = Request{
+		Context: context,
+		Method:  "POST",
+		Body:    body,
+		Version: rest.Version,
+	}
+	request.Url = BuildSimpleUrl(request, "graphql")
+
+	buf, _, err := rest.Request(request)
+	if err != nil {
+		err = errors.WithMessage(err, "there was error while making the graphql request")
+		return
+	}
+
+	err = json.Unmarshal(buf, &response)
+	if err != nil {
+		err = errors.WithMessage(err, "error unmarshaling the graphql response")
+	}
+	return
+}
+
+func (g *GraphQLClient) update(shopName string, cost *GraphQLCost) {
+	if cost == nil {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.buckets == nil {
+		g.buckets = make(map[string]*graphQLBucket)
+	}
+	g.buckets[shopName] = &graphQLBucket{
+		status:    cost.ThrottleStatus,
+		requested: cost.RequestedQueryCost,
+		updated:   time.Now(),
+	}
+}
+
+/*
+How long to wait for the shop to restore the points the previous query asked for.
+*/
+func (g *GraphQLClient) delay(shopName string) time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	b, ok := g.buckets[shopName]
+	if !ok || b.status.RestoreRate <= 0 {
+		return 0
+	}
+
+	needed := b.requested
+	if needed > b.status.MaximumAvailable {
+		needed = b.status.MaximumAvailable
+	}
+
+	available := b.status.CurrentlyAvailable + time.Since(b.updated).Seconds()*b.status.RestoreRate
+	if available >= needed {
+		return 0
+	}
+
+	return time.Duration((needed - available) / b.status.RestoreRate * float64(time.Second))
+}
+
+/*
+Collects the userErrors of every top level field of the data, sorted by field name.
+*/
+func findUserErrors(data []byte) (userErrors GraphQLUserErrors) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var payload struct {
+			UserErrors GraphQLUserErrors `json:"userErrors"`
+		}
+		if err := json.Unmarshal(fields[name], &payload); err != nil {
+			continue
+		}
+
+		for _, userError := range payload.UserErrors {
+			userError.Mutation = name
+			userErrors = append(userErrors, userError)
+		}
+	}
+
+	return
+}
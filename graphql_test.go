@@ -0,0 +1,87 @@
+package shopify
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"net/http"
+	"testing"
+)
+
+func TestGraphQLQuery(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != "POST" || req.URL.Path != "/admin/api/2020-10/graphql.json" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var request graphQLRequest
+		_ = json.NewDecoder(req.Body).Decode(&request)
+		switch request.Query {
+		case "query($id: ID!) { product(id: $id) { title } }":
+			if request.Variables["id"] != "gid://shopify/Product/632910392" {
+				rw.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = rw.Write([]byte(`{"data":{"product":{"title":"IPod Nano - 8GB"}},"extensions":{"cost":{"requestedQueryCost":1,"actualQueryCost":1,
+				"throttleStatus":{"maximumAvailable":1000,"currentlyAvailable":999,"restoreRate":50}}}}`))
+		case "mutation { productCreate(input: {}) { product { id } userErrors { field message } } }":
+			_, _ = rw.Write([]byte(`{"data":{"productCreate":{"product":null,"userErrors":[{"field":["title"],"message":"Title can't be blank"}]}}}`))
+		default:
+			_, _ = rw.Write([]byte(`{"errors":[{"message":"Parse error on \"}\" (RCURLY) at [1, 3]","locations":[{"line":1,"column":3}]}]}`))
+		}
+	})
+	defer server.Close()
+
+	graphQL := NewGraphQLClient(&client)
+
+	var result struct {
+		Product struct {
+			Title string `json:"title"`
+		} `json:"product"`
+	}
+	err := graphQL.Query(requestContext, "query($id: ID!) { product(id: $id) { title } }", map[string]interface{}{"id": "gid://shopify/Product/632910392"}, &result)
+	if err != nil || result.Product.Title != "IPod Nano - 8GB" {
+		t.Fatalf("unexpected result %+v %v", result, err)
+	}
+	if status, ok := graphQL.ThrottleStatus(requestContext.ShopName); !ok || status.CurrentlyAvailable != 999 {
+		t.Errorf("unexpected throttle status %+v", status)
+	}
+
+	err = graphQL.Query(requestContext, "mutation { productCreate(input: {}) { product { id } userErrors { field message } } }", nil, nil)
+	var userErrors GraphQLUserErrors
+	if !errors.As(err, &userErrors) || userErrors[0].Mutation != "productCreate" || userErrors[0].Field[0] != "title" || !IsValidationError(err) {
+		t.Errorf("expected user errors, got %v", err)
+	}
+
+	err = graphQL.Query(requestContext, "{ }", nil, nil)
+	var graphQLErrors GraphQLErrors
+	if !errors.As(err, &graphQLErrors) || graphQLErrors[0].Locations[0].Column != 3 {
+		t.Errorf("expected top level errors, got %v", err)
+	}
+}
+
+func TestGraphQLRetriesThrottledQueries(t *testing.T) {
+	attempts := 0
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		if attempts == 1 {
+			_, _ = rw.Write([]byte(`{"errors":[{"message":"Throttled","extensions":{"code":"THROTTLED"}}],"extensions":{"cost":{"requestedQueryCost":10,
+				"throttleStatus":{"maximumAvailable":1000,"currentlyAvailable":5,"restoreRate":1000}}}}`))
+			return
+		}
+		_, _ = rw.Write([]byte(`{"data":{"shop":{"name":"Apple Computers"}},"extensions":{"cost":{"requestedQueryCost":10,"actualQueryCost":10,
+			"throttleStatus":{"maximumAvailable":1000,"currentlyAvailable":990,"restoreRate":1000}}}}`))
+	})
+	defer server.Close()
+
+	graphQL := NewGraphQLClient(&client)
+	var result struct {
+		Shop struct {
+			Name string `json:"name"`
+		} `json:"shop"`
+	}
+	err := graphQL.Query(requestContext, "{ shop { name } }", nil, &result)
+	if err != nil || attempts != 2 || result.Shop.Name != "Apple Computers" {
+		t.Errorf("unexpected result %+v %v after %v attempts", result, err, attempts)
+	}
+}
@@ -0,0 +1,308 @@
+package shopify
+
+import (
+	"encoding/json"
+	"github.com/google/go-querystring/query"
+	"github.com/pkg/errors"
+)
+
+/*
+RequiresShipping and Tracked are pointers so that false can be sent in an update, for example
+Tracked: Bool(false) to stop tracking the item. They are left out when nil.
+*/
+type InventoryItem struct {
+	CountryCodeOfOrigin          string                        `json:"country_code_of_origin,omitempty"`
+	CountryHarmonizedSystemCodes []CountryHarmonizedSystemCode `json:"country_harmonized_system_codes,omitempty"`
+	Cost                         string                        `json:"cost,omitempty"`
+	CreatedAt                    string                        `json:"created_at,omitempty"`
+	HarmonizedSystemCode         string                        `json:"harmonized_system_code,omitempty"`
+	Id                           int                           `json:"id,omitempty"`
+	ProvinceCodeOfOrigin         string                        `json:"province_code_of_origin,omitempty"`
+	RequiresShipping             *bool                         `json:"requires_shipping,omitempty"`
+	Sku                          string                        `json:"sku,omitempty"`
+	Tracked                      *bool                         `json:"tracked,omitempty"`
+	UpdatedAt                    string                        `json:"updated_at,omitempty"`
+}
+
+type CountryHarmonizedSystemCode struct {
+	CountryCode          string `json:"country_code"`
+	HarmonizedSystemCode string `json:"harmonized_system_code"`
+}
+
+/*
+The stock of an inventory item at a location. Available is nil when the item isn't tracked.
+*/
+type InventoryLevel struct {
+	Available       *int   `json:"available"`
+	InventoryItemId int    `json:"inventory_item_id"`
+	LocationId      int    `json:"location_id"`
+	UpdatedAt       string `json:"updated_at,omitempty"`
+}
+
+type InventoryItemWrapper struct {
+	InventoryItem *InventoryItem `json:"inventory_item"`
+}
+
+func (i InventoryItemWrapper) GetResourceName() string {
+	return "inventory_items"
+}
+
+func (i InventoryItemWrapper) GetId() int {
+	return i.InventoryItem.Id
+}
+
+func (i InventoryItemWrapper) BuildGetUrl(request Request) string {
+	return BuildIdUrl(request, i.GetResourceName(), i.GetId())
+}
+
+func (i InventoryItemWrapper) BuildUpdateUrl(request Request) string {
+	return BuildIdUrl(request, i.GetResourceName(), i.GetId())
+}
+
+type InventoryItems struct {
+	InventoryItems []InventoryItem `json:"inventory_items"`
+}
+
+type InventoryItemsWrapper struct {
+	InventoryItems []InventoryItem
+}
+
+func (i *InventoryItemsWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper InventoryItems
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return
+	}
+
+	i.InventoryItems = append(i.InventoryItems, wrapper.InventoryItems...)
+	return
+}
+
+func (i InventoryItemsWrapper) GetResourceName() string {
+	return "inventory_items"
+}
+
+type InventoryLevels struct {
+	InventoryLevels []InventoryLevel `json:"inventory_levels"`
+}
+
+/*
+Holds the inventory levels of a list request. With a LocationId the levels are requested
+through the location, otherwise through the inventory_levels endpoint.
+*/
+type InventoryLevelsWrapper struct {
+	LocationId      int
+	InventoryLevels []InventoryLevel
+}
+
+func (i *InventoryLevelsWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper InventoryLevels
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return
+	}
+
+	i.InventoryLevels = append(i.InventoryLevels, wrapper.InventoryLevels...)
+	return
+}
+
+func (i InventoryLevelsWrapper) GetResourceName() string {
+	if i.LocationId != 0 {
+		return locationPath(i.LocationId) + "/inventory_levels"
+	}
+
+	return "inventory_levels"
+}
+
+type inventoryLevelWrapper struct {
+	InventoryLevel InventoryLevel `json:"inventory_level"`
+}
+
+/*
+Ids is required by Shopify and takes at most 100 ids.
+*/
+type InventoryItemRequestOptions struct {
+	Ids   []int `url:"ids,comma"`
+	Limit int   `url:"limit,omitempty"`
+}
+
+func (i InventoryItemRequestOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(i)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", i)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
+}
+
+/*
+At least one of InventoryItemIds or LocationIds is required by Shopify.
+*/
+type InventoryLevelRequestOptions struct {
+	InventoryItemIds []int  `url:"inventory_item_ids,omitempty,comma"`
+	Limit            int    `url:"limit,omitempty"`
+	LocationIds      []int  `url:"location_ids,omitempty,comma"`
+	UpdatedAtMin     string `url:"updated_at_min,omitempty"`
+}
+
+func (i InventoryLevelRequestOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(i)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", i)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
+}
+
+type inventoryLevelDeleteOptions struct {
+	InventoryItemId int `url:"inventory_item_id"`
+	LocationId      int `url:"location_id"`
+}
+
+func (i inventoryLevelDeleteOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(i)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", i)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
+}
+
+func (r *RestAdminClient) InventoryItemList(context Ctx, options InventoryItemRequestOptions) (results []InventoryItem, next string, err error) {
+	var wrapper = &InventoryItemsWrapper{}
+	next, err = r.List(context, options, wrapper)
+	results = wrapper.InventoryItems
+	return
+}
+
+type InventoryItemIterator struct {
+	*Iterator
+}
+
+/*
+The inventory items on the current page.
+*/
+func (i *InventoryItemIterator) InventoryItems() []InventoryItem {
+	if i.Page() == nil {
+		return nil
+	}
+
+	return i.Page().(*InventoryItemsWrapper).InventoryItems
+}
+
+func (r *RestAdminClient) InventoryItemListIterator(context Ctx, options InventoryItemRequestOptions) *InventoryItemIterator {
+	return &InventoryItemIterator{r.NewIterator(context, options, func() Lister { return &InventoryItemsWrapper{} })}
+}
+
+func (r *RestAdminClient) InventoryItemGet(context Ctx, id int) (result *InventoryItem, err error) {
+	wrapper := &InventoryItemWrapper{InventoryItem: &InventoryItem{Id: id}}
+	err = r.Get(context, wrapper)
+	result = wrapper.InventoryItem
+
+	return
+}
+
+func (r *RestAdminClient) InventoryItemUpdate(context Ctx, request InventoryItem) (result *InventoryItem, err error) {
+	var returnWrapper = new(InventoryItemWrapper)
+	err = r.Update(context, returnWrapper, InventoryItemWrapper{InventoryItem: &request})
+	result = returnWrapper.InventoryItem
+
+	return
+}
+
+func (r *RestAdminClient) InventoryLevelList(context Ctx, options InventoryLevelRequestOptions) (results []InventoryLevel, next string, err error) {
+	var wrapper = &InventoryLevelsWrapper{}
+	next, err = r.List(context, options, wrapper)
+	results = wrapper.InventoryLevels
+	return
+}
+
+type InventoryLevelIterator struct {
+	*Iterator
+}
+
+/*
+The inventory levels on the current page.
+*/
+func (i *InventoryLevelIterator) InventoryLevels() []InventoryLevel {
+	if i.Page() == nil {
+		return nil
+	}
+
+	return i.Page().(*InventoryLevelsWrapper).InventoryLevels
+}
+
+func (r *RestAdminClient) InventoryLevelListIterator(context Ctx, options InventoryLevelRequestOptions) *InventoryLevelIterator {
+	return &InventoryLevelIterator{r.NewIterator(context, options, func() Lister { return &InventoryLevelsWrapper{} })}
+}
+
+/*
+Changes the available quantity of an item at a location by the adjustment, which may be negative.
+*/
+func (r *RestAdminClient) InventoryLevelAdjust(context Ctx, locationId int, inventoryItemId int, adjustment int) (result InventoryLevel, err error) {
+	body := map[string]int{
+		"location_id":          locationId,
+		"inventory_item_id":    inventoryItemId,
+		"available_adjustment": adjustment,
+	}
+
+	return r.inventoryLevelAction(context, "adjust", body)
+}
+
+/*
+Sets the available quantity of an item at a location. When disconnectIfNecessary is set the item
+is disconnected from any location that can't stock it because of the fulfillment service.
+*/
+func (r *RestAdminClient) InventoryLevelSet(context Ctx, locationId int, inventoryItemId int, available int, disconnectIfNecessary bool) (result InventoryLevel, err error) {
+	body := map[string]interface{}{
+		"location_id":             locationId,
+		"inventory_item_id":       inventoryItemId,
+		"available":               available,
+		"disconnect_if_necessary": disconnectIfNecessary,
+	}
+
+	return r.inventoryLevelAction(context, "set", body)
+}
+
+/*
+Connects an item to a location so it can be stocked there. When relocateIfNecessary is set the item
+is moved away from a location that can't be connected at the same time.
+*/
+func (r *RestAdminClient) InventoryLevelConnect(context Ctx, locationId int, inventoryItemId int, relocateIfNecessary bool) (result InventoryLevel, err error) {
+	body := map[string]interface{}{
+		"location_id":           locationId,
+		"inventory_item_id":     inventoryItemId,
+		"relocate_if_necessary": relocateIfNecessary,
+	}
+
+	return r.inventoryLevelAction(context, "connect", body)
+}
+
+/*
+Removes an item from a location, the item must stay connected to at least one location.
+*/
+func (r *RestAdminClient) InventoryLevelDelete(context Ctx, locationId int, inventoryItemId int) (err error) {
+	options := inventoryLevelDeleteOptions{InventoryItemId: inventoryItemId, LocationId: locationId}
+	err = r.Send(context, "DELETE", "inventory_levels", options, nil, nil)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to delete inventory level of item %v at location %v", inventoryItemId, locationId)
+	}
+
+	return
+}
+
+func (r *RestAdminClient) inventoryLevelAction(context Ctx, action string, body interface{}) (result InventoryLevel, err error) {
+	var wrapper inventoryLevelWrapper
+	err = r.Send(context, "POST", "inventory_levels/"+action, nil, body, &wrapper)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to %v the inventory level", action)
+		return
+	}
+	result = wrapper.InventoryLevel
+
+	return
+}
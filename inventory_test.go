@@ -0,0 +1,90 @@
+package shopify
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestInventoryLevelAdjustAndDelete(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/inventory_levels/adjust.json":
+			var body map[string]int
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			if body["location_id"] != 905684977 || body["inventory_item_id"] != 808950810 || body["available_adjustment"] != -5 {
+				rw.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			_, _ = rw.Write([]byte(`{"inventory_level":{"inventory_item_id":808950810,"location_id":905684977,"available":1}}`))
+		case req.Method == "DELETE" && req.URL.Path == "/admin/api/2020-10/inventory_levels.json" &&
+			req.URL.RawQuery == "inventory_item_id=808950810&location_id=905684977":
+			rw.WriteHeader(http.StatusNoContent)
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	level, err := client.InventoryLevelAdjust(requestContext, 905684977, 808950810, -5)
+	if err != nil || level.Available == nil || *level.Available != 1 {
+		t.Errorf("unexpected inventory level %+v %v", level, err)
+	}
+
+	err = client.InventoryLevelDelete(requestContext, 905684977, 808950810)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInventoryItemUpdateStopsTracking(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != "PUT" || req.URL.Path != "/admin/api/2020-10/inventory_items/808950810.json" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var raw map[string]map[string]interface{}
+		_ = json.NewDecoder(req.Body).Decode(&raw)
+		tracked, sent := raw["inventory_item"]["tracked"]
+		if _, requiresShipping := raw["inventory_item"]["requires_shipping"]; !sent || tracked != false || requiresShipping {
+			rw.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`{"inventory_item":{"id":808950810,"sku":"IPOD2008PINK","tracked":false,"requires_shipping":true}}`))
+	})
+	defer server.Close()
+
+	item, err := client.InventoryItemUpdate(requestContext, InventoryItem{Id: 808950810, Tracked: Bool(false)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Tracked == nil || *item.Tracked || item.RequiresShipping == nil || !*item.RequiresShipping {
+		t.Errorf("unexpected inventory item %+v", item)
+	}
+}
+
+func TestLocationInventoryLevels(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/admin/api/2020-10/locations.json":
+			_, _ = rw.Write([]byte(`{"locations":[{"id":487838322,"name":"Fifth Avenue AppleStore","active":true}]}`))
+		case "/admin/api/2020-10/locations/487838322/inventory_levels.json":
+			_, _ = rw.Write([]byte(`{"inventory_levels":[{"inventory_item_id":49148385,"location_id":487838322,"available":2},{"inventory_item_id":39072856,"location_id":487838322,"available":null}]}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	locations, err := client.LocationList(requestContext)
+	if err != nil || len(locations) != 1 || !locations[0].Active {
+		t.Fatalf("unexpected locations %+v %v", locations, err)
+	}
+
+	levels, _, err := client.LocationInventoryLevels(requestContext, locations[0].Id, LocationInventoryLevelOptions{})
+	if err != nil || len(levels) != 2 || *levels[0].Available != 2 || levels[1].Available != nil {
+		t.Errorf("unexpected inventory levels %+v %v", levels, err)
+	}
+}
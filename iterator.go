@@ -0,0 +1,147 @@
+package shopify
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+)
+
+/*
+Iterator walks a list endpoint one page at a time by following the cursor urls in the Link header,
+so only the current page is held in memory. Every call to Next or Previous decodes into a fresh
+resource created by newPage.
+
+	iter := client.ProductListIterator(ctx, options)
+	for iter.Next() {
+		for _, product := range iter.Products() {
+			...
+		}
+	}
+	if iter.Err() != nil {
+		...
+	}
+*/
+type Iterator struct {
+	client  *RestAdminClient
+	context Ctx
+	options QueryParamStringer
+	newPage func() Lister
+
+	page    Lister
+	links   Links
+	started bool
+	err     error
+}
+
+/*
+Creates an iterator over a list endpoint. When the context has a CursorUrl the iteration starts there,
+otherwise the first page is built from the options.
+*/
+func (r *RestAdminClient) NewIterator(context Ctx, options QueryParamStringer, newPage func() Lister) *Iterator {
+	return &Iterator{
+		client:  r,
+		context: context,
+		options: options,
+		newPage: newPage,
+	}
+}
+
+/*
+Advances to the next page, returning false when there are no more pages or a request failed.
+*/
+func (i *Iterator) Next() bool {
+	if i.err != nil {
+		return false
+	}
+
+	if !i.started {
+		request := Request{Context: i.context, Version: i.client.Version}
+		url, err := buildListUrl(request, i.options, i.newPage())
+		if err != nil {
+			i.err = errors.WithMessage(err, "unable to build the first page url")
+			return false
+		}
+
+		return i.fetch(url)
+	}
+
+	next := i.links.Next()
+	if next == "" {
+		return false
+	}
+
+	return i.fetch(next)
+}
+
+/*
+Moves back to the page before the current one, returning false on the first page or when the request failed.
+*/
+func (i *Iterator) Previous() bool {
+	previous := i.links.Previous()
+	if i.err != nil || previous == "" {
+		return false
+	}
+
+	return i.fetch(previous)
+}
+
+/*
+The decoded current page, nil until Next has been called.
+*/
+func (i *Iterator) Page() Lister {
+	return i.page
+}
+
+/*
+The error that stopped the iteration, if any.
+*/
+func (i *Iterator) Err() error {
+	return i.err
+}
+
+/*
+The cursor url of the page after the current one, it can be stored in Ctx.CursorUrl to resume later.
+*/
+func (i *Iterator) NextCursor() string {
+	return i.links.Next()
+}
+
+/*
+The cursor url of the page before the current one.
+*/
+func (i *Iterator) PreviousCursor() string {
+	return i.links.Previous()
+}
+
+/*
+Every relation from the Link header of the current page.
+*/
+func (i *Iterator) Links() Links {
+	return i.links
+}
+
+func (i *Iterator) fetch(url string) bool {
+	request := Request{
+		Context: i.context,
+		Method:  "GET",
+		Url:     url,
+		Version: i.client.Version,
+	}
+
+	buf, links, err := i.client.RequestLinks(request)
+	if err != nil {
+		i.err = errors.WithMessage(err, "failure while requesting page")
+		return false
+	}
+
+	page := i.newPage()
+	err = json.Unmarshal(buf, &page)
+	if err != nil {
+		i.err = errors.WithMessage(err, "error while unmarshalling page response")
+		return false
+	}
+
+	i.started = true
+	i.page = page
+	i.links = links
+	return true
+}
@@ -0,0 +1,97 @@
+package shopify
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+)
+
+func TestProductListIterator(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/admin/api/2019-07/products.json" || req.Header.Get("X-Shopify-Access-Token") != "thisisatoken" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch req.URL.Query().Get("page_info") {
+		case "":
+			rw.Header().Set("Link", "<https://"+req.Host+"/admin/api/2019-07/products.json?page_info=two&limit=1>; rel=\"next\"")
+			_, _ = rw.Write([]byte(`{"products":[{"id":1}]}`))
+		case "two":
+			rw.Header().Set("Link", "<https://"+req.Host+"/admin/api/2019-07/products.json?page_info=one&limit=1>; rel=\"previous\", "+
+				"<https://"+req.Host+"/admin/api/2019-07/products.json?page_info=three&limit=1>; rel=\"next\"")
+			_, _ = rw.Write([]byte(`{"products":[{"id":2}]}`))
+		case "three":
+			rw.Header().Set("Link", "<https://"+req.Host+"/admin/api/2019-07/products.json?page_info=two&limit=1>; rel=\"previous\"")
+			_, _ = rw.Write([]byte(`{"products":[{"id":3}]}`))
+		case "one":
+			rw.Header().Set("Link", "<https://"+req.Host+"/admin/api/2019-07/products.json?page_info=two&limit=1>; rel=\"next\"")
+			_, _ = rw.Write([]byte(`{"products":[{"id":1}]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := RestAdminClient{
+		Http:    server.Client(),
+		Logger:  log.New(os.Stdout, "", log.Ldate|log.Ltime|log.Lshortfile),
+		Version: VERSION_2019_07,
+	}
+
+	serverUrl, _ := url.Parse(server.URL)
+	requestContext := Ctx{
+		AccessToken: "thisisatoken",
+		ShopName:    serverUrl.Host,
+		Ctx:         context.Background(),
+	}
+
+	iter := client.ProductListIterator(requestContext, ProductRequestOptions{Limit: 1})
+	var ids []int
+	for iter.Next() {
+		for _, product := range iter.Products() {
+			ids = append(ids, product.Id)
+		}
+	}
+	if iter.Err() != nil {
+		t.Fatal(iter.Err())
+	}
+	if len(ids) != 3 || ids[0] != 1 || ids[1] != 2 || ids[2] != 3 {
+		t.Errorf("unexpected products from the iterator %v", ids)
+	}
+
+	if !iter.Previous() || iter.Products()[0].Id != 2 {
+		t.Errorf("expected to move back to the second page %v", iter.Err())
+	}
+	if !iter.Previous() || iter.Products()[0].Id != 1 {
+		t.Errorf("expected to move back to the first page %v", iter.Err())
+	}
+	if iter.Previous() {
+		t.Error("there should be no page before the first page")
+	}
+}
+
+func TestIteratorStopsOnError(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusUnauthorized)
+		_, _ = rw.Write([]byte(`{"errors":"[API] Invalid API key or access token"}`))
+	}))
+	defer server.Close()
+
+	client := RestAdminClient{
+		Http:    server.Client(),
+		Logger:  log.New(os.Stdout, "", log.Ldate|log.Ltime|log.Lshortfile),
+		Version: VERSION_2019_07,
+	}
+
+	serverUrl, _ := url.Parse(server.URL)
+	iter := client.WebhookListIterator(Ctx{ShopName: serverUrl.Host, Ctx: context.Background()}, WebHookRequestOptions{})
+	if iter.Next() {
+		t.Error("expected the iterator to stop")
+	}
+	if !IsUnauthorized(iter.Err()) {
+		t.Errorf("expected an unauthorized error, got %v", iter.Err())
+	}
+}
@@ -0,0 +1,114 @@
+package shopify
+
+import (
+	"github.com/google/go-querystring/query"
+	"github.com/pkg/errors"
+	"strconv"
+)
+
+type Location struct {
+	Active                bool   `json:"active"`
+	Address1              string `json:"address1,omitempty"`
+	Address2              string `json:"address2,omitempty"`
+	City                  string `json:"city,omitempty"`
+	Country               string `json:"country,omitempty"`
+	CountryCode           string `json:"country_code,omitempty"`
+	CountryName           string `json:"country_name,omitempty"`
+	CreatedAt             string `json:"created_at,omitempty"`
+	Id                    int    `json:"id,omitempty"`
+	Legacy                bool   `json:"legacy"`
+	LocalizedCountryName  string `json:"localized_country_name,omitempty"`
+	LocalizedProvinceName string `json:"localized_province_name,omitempty"`
+	Name                  string `json:"name,omitempty"`
+	Phone                 string `json:"phone,omitempty"`
+	Province              string `json:"province,omitempty"`
+	ProvinceCode          string `json:"province_code,omitempty"`
+	UpdatedAt             string `json:"updated_at,omitempty"`
+	Zip                   string `json:"zip,omitempty"`
+}
+
+type LocationWrapper struct {
+	Location *Location `json:"location"`
+}
+
+func (l LocationWrapper) GetResourceName() string {
+	return "locations"
+}
+
+func (l LocationWrapper) GetId() int {
+	return l.Location.Id
+}
+
+func (l LocationWrapper) BuildGetUrl(request Request) string {
+	return BuildIdUrl(request, l.GetResourceName(), l.GetId())
+}
+
+type LocationsWrapper struct {
+	Locations []Location `json:"locations"`
+}
+
+func (l LocationsWrapper) GetResourceName() string {
+	return "locations"
+}
+
+type LocationInventoryLevelOptions struct {
+	Limit int `url:"limit,omitempty"`
+}
+
+func (l LocationInventoryLevelOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(l)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", l)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
+}
+
+type emptyOptions struct{}
+
+func (e emptyOptions) UrlOptionsString() (queryParams string, err error) {
+	return
+}
+
+/*
+Lists every location of the shop, the endpoint isn't paginated.
+*/
+func (r *RestAdminClient) LocationList(context Ctx) (results []Location, err error) {
+	var wrapper = &LocationsWrapper{}
+	context.AutoPaginate = false
+	_, err = r.List(context, emptyOptions{}, wrapper)
+	results = wrapper.Locations
+	return
+}
+
+func (r *RestAdminClient) LocationGet(context Ctx, id int) (result *Location, err error) {
+	wrapper := &LocationWrapper{Location: &Location{Id: id}}
+	err = r.Get(context, wrapper)
+	result = wrapper.Location
+
+	return
+}
+
+func (r *RestAdminClient) LocationCount(context Ctx) (count int, err error) {
+	return r.Count(context, "locations", nil)
+}
+
+/*
+Lists the stock of every inventory item connected to the location.
+*/
+func (r *RestAdminClient) LocationInventoryLevels(context Ctx, locationId int, options LocationInventoryLevelOptions) (results []InventoryLevel, next string, err error) {
+	var wrapper = &InventoryLevelsWrapper{LocationId: locationId}
+	next, err = r.List(context, options, wrapper)
+	results = wrapper.InventoryLevels
+	return
+}
+
+func (r *RestAdminClient) LocationInventoryLevelsIterator(context Ctx, locationId int, options LocationInventoryLevelOptions) *InventoryLevelIterator {
+	return &InventoryLevelIterator{r.NewIterator(context, options, func() Lister { return &InventoryLevelsWrapper{LocationId: locationId} })}
+}
+
+func locationPath(id int) string {
+	return "locations/" + strconv.Itoa(id)
+}
@@ -0,0 +1,447 @@
+package shopify
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/google/go-querystring/query"
+	"github.com/pkg/errors"
+	"strconv"
+	"time"
+)
+
+/*
+The resources metafields can belong to, use them as the Resource of a MetafieldOwner.
+*/
+const (
+	MetafieldOwnerShop       = "shop"
+	MetafieldOwnerProduct    = "products"
+	MetafieldOwnerVariant    = "variants"
+	MetafieldOwnerCollection = "collections"
+	MetafieldOwnerCustomer   = "customers"
+	MetafieldOwnerOrder      = "orders"
+)
+
+/*
+The value_type of a metafield in the older api versions.
+*/
+const (
+	MetafieldValueTypeInteger    = "integer"
+	MetafieldValueTypeString     = "string"
+	MetafieldValueTypeJsonString = "json_string"
+)
+
+/*
+The type of a metafield in the newer api versions, these values are always sent as strings.
+*/
+const (
+	MetafieldTypeSingleLineText   = "single_line_text_field"
+	MetafieldTypeMultiLineText    = "multi_line_text_field"
+	MetafieldTypeNumberInteger    = "number_integer"
+	MetafieldTypeNumberDecimal    = "number_decimal"
+	MetafieldTypeBoolean          = "boolean"
+	MetafieldTypeDate             = "date"
+	MetafieldTypeDateTime         = "date_time"
+	MetafieldTypeJson             = "json"
+	MetafieldTypeUrl              = "url"
+	MetafieldTypeColor            = "color"
+	MetafieldTypeWeight           = "weight"
+	MetafieldTypeVolume           = "volume"
+	MetafieldTypeDimension        = "dimension"
+	MetafieldTypeRating           = "rating"
+	MetafieldTypeProductReference = "product_reference"
+	MetafieldTypeVariantReference = "variant_reference"
+	MetafieldTypePageReference    = "page_reference"
+	MetafieldTypeFileReference    = "file_reference"
+)
+
+const metafieldDateLayout = "2006-01-02"
+
+/*
+A metafield of a shop, product, variant, collection, customer or order. Value holds the raw json value
+as Shopify sends it, a number for integer metafields and a string otherwise. Use SetValue to set it
+and the typed helpers, or Decode, to read it.
+*/
+type Metafield struct {
+	Id            int             `json:"id,omitempty"`
+	Namespace     string          `json:"namespace,omitempty"`
+	Key           string          `json:"key,omitempty"`
+	Value         json.RawMessage `json:"value,omitempty"`
+	ValueType     string          `json:"value_type,omitempty"`
+	Type          string          `json:"type,omitempty"`
+	Description   string          `json:"description,omitempty"`
+	OwnerId       int             `json:"owner_id,omitempty"`
+	OwnerResource string          `json:"owner_resource,omitempty"`
+	CreatedAt     string          `json:"created_at,omitempty"`
+	UpdatedAt     string          `json:"updated_at,omitempty"`
+}
+
+/*
+The resource a metafield belongs to. Id is ignored for the shop.
+*/
+type MetafieldOwner struct {
+	Resource string
+	Id       int
+}
+
+func (o MetafieldOwner) validate() error {
+	switch o.Resource {
+	case MetafieldOwnerShop:
+		return nil
+	case MetafieldOwnerProduct, MetafieldOwnerVariant, MetafieldOwnerCollection, MetafieldOwnerCustomer, MetafieldOwnerOrder:
+		if o.Id == 0 {
+			return errors.Errorf("the %v metafield owner needs an id", o.Resource)
+		}
+		return nil
+	default:
+		return errors.Errorf("%q can't own metafields", o.Resource)
+	}
+}
+
+func (o MetafieldOwner) path() string {
+	if o.Resource == MetafieldOwnerShop {
+		return "metafields"
+	}
+
+	return o.Resource + "/" + strconv.Itoa(o.Id) + "/metafields"
+}
+
+/*
+Sets the value, encoding it the way Shopify expects for the metafield's type. With a Type set the
+value is sent as a string, for example 12 becomes "12" and a struct for a json metafield becomes
+its json text. With only a ValueType set integers are sent as numbers and json_string values are
+encoded to json text when they aren't a string already. Times are formatted as dates for date
+metafields and as RFC 3339 otherwise.
+*/
+func (m *Metafield) SetValue(value interface{}) (err error) {
+	var text string
+	switch v := value.(type) {
+	case string:
+		text = v
+	case int:
+		text = strconv.Itoa(v)
+	case int64:
+		text = strconv.FormatInt(v, 10)
+	case float64:
+		text = strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		text = strconv.FormatBool(v)
+	case time.Time:
+		if m.Type == MetafieldTypeDate {
+			text = v.Format(metafieldDateLayout)
+		} else {
+			text = v.Format(time.RFC3339)
+		}
+	default:
+		encoded, encodeErr := json.Marshal(value)
+		if encodeErr != nil {
+			err = errors.WithMessagef(encodeErr, "unable to encode the value of metafield %v.%v", m.Namespace, m.Key)
+			return
+		}
+		text = string(encoded)
+	}
+
+	if m.Type == "" && m.ValueType == MetafieldValueTypeInteger {
+		if _, err = strconv.ParseInt(text, 10, 64); err != nil {
+			err = errors.Errorf("%q is not a value for the integer metafield %v.%v", text, m.Namespace, m.Key)
+			return
+		}
+		m.Value = json.RawMessage(text)
+		return
+	}
+
+	m.Value, err = json.Marshal(text)
+	return
+}
+
+/*
+The value as text, numbers are returned as they were sent.
+*/
+func (m Metafield) StringValue() (value string, err error) {
+	raw := bytes.TrimSpace(m.Value)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return
+	}
+
+	if raw[0] != '"' {
+		value = string(raw)
+		return
+	}
+
+	err = json.Unmarshal(raw, &value)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to decode the value of metafield %v.%v", m.Namespace, m.Key)
+	}
+	return
+}
+
+func (m Metafield) IntValue() (value int64, err error) {
+	text, err := m.StringValue()
+	if err != nil {
+		return
+	}
+
+	value, err = strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		err = errors.Errorf("the value of metafield %v.%v is not an integer: %q", m.Namespace, m.Key, text)
+	}
+	return
+}
+
+func (m Metafield) FloatValue() (value float64, err error) {
+	text, err := m.StringValue()
+	if err != nil {
+		return
+	}
+
+	value, err = strconv.ParseFloat(text, 64)
+	if err != nil {
+		err = errors.Errorf("the value of metafield %v.%v is not a number: %q", m.Namespace, m.Key, text)
+	}
+	return
+}
+
+func (m Metafield) BoolValue() (value bool, err error) {
+	text, err := m.StringValue()
+	if err != nil {
+		return
+	}
+
+	value, err = strconv.ParseBool(text)
+	if err != nil {
+		err = errors.Errorf("the value of metafield %v.%v is not a boolean: %q", m.Namespace, m.Key, text)
+	}
+	return
+}
+
+/*
+Parses date metafields as a day and every other value as RFC 3339.
+*/
+func (m Metafield) TimeValue() (value time.Time, err error) {
+	text, err := m.StringValue()
+	if err != nil {
+		return
+	}
+
+	layout := time.RFC3339
+	if m.Type == MetafieldTypeDate || len(text) == len(metafieldDateLayout) {
+		layout = metafieldDateLayout
+	}
+
+	value, err = time.Parse(layout, text)
+	if err != nil {
+		err = errors.WithMessagef(err, "the value of metafield %v.%v is not a time", m.Namespace, m.Key)
+	}
+	return
+}
+
+/*
+Decodes a json, json_string, weight, volume, dimension or rating value into target.
+*/
+func (m Metafield) JsonValue(target interface{}) (err error) {
+	raw := bytes.TrimSpace(m.Value)
+	if len(raw) > 0 && raw[0] == '"' {
+		var text string
+		if text, err = m.StringValue(); err != nil {
+			return
+		}
+		raw = []byte(text)
+	}
+
+	err = json.Unmarshal(raw, target)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to decode the json value of metafield %v.%v", m.Namespace, m.Key)
+	}
+	return
+}
+
+/*
+Decodes the value into the Go type that matches the metafield's type or value_type: int64 for
+integers, float64 for decimals, bool, time.Time for dates, the generic json decoding for json
+values and a string for everything else.
+*/
+func (m Metafield) Decode() (value interface{}, err error) {
+	valueType := m.Type
+	if valueType == "" {
+		valueType = m.ValueType
+	}
+
+	switch valueType {
+	case MetafieldValueTypeInteger, MetafieldTypeNumberInteger:
+		return m.IntValue()
+	case MetafieldTypeNumberDecimal:
+		return m.FloatValue()
+	case MetafieldTypeBoolean:
+		return m.BoolValue()
+	case MetafieldTypeDate, MetafieldTypeDateTime:
+		return m.TimeValue()
+	case MetafieldValueTypeJsonString, MetafieldTypeJson, MetafieldTypeWeight, MetafieldTypeVolume, MetafieldTypeDimension, MetafieldTypeRating:
+		err = m.JsonValue(&value)
+		return
+	default:
+		return m.StringValue()
+	}
+}
+
+type MetafieldWrapper struct {
+	Owner     MetafieldOwner `json:"-"`
+	Metafield *Metafield     `json:"metafield"`
+}
+
+func (m MetafieldWrapper) GetResourceName() string {
+	return m.Owner.path()
+}
+
+func (m MetafieldWrapper) GetId() int {
+	return m.Metafield.Id
+}
+
+func (m MetafieldWrapper) BuildGetUrl(request Request) string {
+	return BuildIdUrl(request, m.GetResourceName(), m.GetId())
+}
+
+func (m MetafieldWrapper) BuildCreateUrl(request Request) string {
+	return BuildSimpleUrl(request, m.GetResourceName())
+}
+
+func (m MetafieldWrapper) BuildUpdateUrl(request Request) string {
+	return BuildIdUrl(request, m.GetResourceName(), m.GetId())
+}
+
+type Metafields struct {
+	Metafields []Metafield `json:"metafields"`
+}
+
+type MetafieldsWrapper struct {
+	Owner      MetafieldOwner
+	Metafields []Metafield
+}
+
+func (m *MetafieldsWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper Metafields
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return
+	}
+
+	m.Metafields = append(m.Metafields, wrapper.Metafields...)
+	return
+}
+
+func (m MetafieldsWrapper) GetResourceName() string {
+	return m.Owner.path()
+}
+
+type MetafieldRequestOptions struct {
+	Limit        int      `url:"limit,omitempty"`
+	SinceId      int      `url:"since_id,omitempty"`
+	Namespace    string   `url:"namespace,omitempty"`
+	Key          string   `url:"key,omitempty"`
+	ValueType    string   `url:"value_type,omitempty"`
+	Type         string   `url:"type,omitempty"`
+	CreatedAtMin string   `url:"created_at_min,omitempty"`
+	CreatedAtMax string   `url:"created_at_max,omitempty"`
+	UpdatedAtMin string   `url:"updated_at_min,omitempty"`
+	UpdatedAtMax string   `url:"updated_at_max,omitempty"`
+	Fields       []string `url:"fields,omitempty,comma"`
+}
+
+func (m MetafieldRequestOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(m)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", m)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
+}
+
+func (r *RestAdminClient) MetafieldList(context Ctx, owner MetafieldOwner, options MetafieldRequestOptions) (results []Metafield, next string, err error) {
+	if err = owner.validate(); err != nil {
+		return
+	}
+
+	var wrapper = &MetafieldsWrapper{Owner: owner}
+	next, err = r.List(context, options, wrapper)
+	results = wrapper.Metafields
+	return
+}
+
+type MetafieldIterator struct {
+	*Iterator
+}
+
+/*
+The metafields on the current page.
+*/
+func (i *MetafieldIterator) Metafields() []Metafield {
+	if i.Page() == nil {
+		return nil
+	}
+
+	return i.Page().(*MetafieldsWrapper).Metafields
+}
+
+/*
+An invalid owner gives an iterator that stops straight away with the validation error in Err.
+*/
+func (r *RestAdminClient) MetafieldListIterator(context Ctx, owner MetafieldOwner, options MetafieldRequestOptions) *MetafieldIterator {
+	iterator := r.NewIterator(context, options, func() Lister { return &MetafieldsWrapper{Owner: owner} })
+	iterator.err = owner.validate()
+
+	return &MetafieldIterator{iterator}
+}
+
+func (r *RestAdminClient) MetafieldGet(context Ctx, owner MetafieldOwner, id int) (result *Metafield, err error) {
+	if err = owner.validate(); err != nil {
+		return
+	}
+
+	wrapper := &MetafieldWrapper{Owner: owner, Metafield: &Metafield{Id: id}}
+	err = r.Get(context, wrapper)
+	result = wrapper.Metafield
+
+	return
+}
+
+/*
+Counts the owner's metafields, the namespace and key filters of the options are applied.
+*/
+func (r *RestAdminClient) MetafieldCount(context Ctx, owner MetafieldOwner, options MetafieldRequestOptions) (count int, err error) {
+	if err = owner.validate(); err != nil {
+		return
+	}
+
+	return r.Count(context, owner.path(), options)
+}
+
+func (r *RestAdminClient) MetafieldCreate(context Ctx, owner MetafieldOwner, request Metafield) (result *Metafield, err error) {
+	if err = owner.validate(); err != nil {
+		return
+	}
+
+	var returnWrapper = new(MetafieldWrapper)
+	err = r.Create(context, returnWrapper, MetafieldWrapper{Owner: owner, Metafield: &request})
+	result = returnWrapper.Metafield
+
+	return
+}
+
+func (r *RestAdminClient) MetafieldUpdate(context Ctx, owner MetafieldOwner, request Metafield) (result *Metafield, err error) {
+	if err = owner.validate(); err != nil {
+		return
+	}
+
+	var returnWrapper = new(MetafieldWrapper)
+	err = r.Update(context, returnWrapper, MetafieldWrapper{Owner: owner, Metafield: &request})
+	result = returnWrapper.Metafield
+
+	return
+}
+
+func (r *RestAdminClient) MetafieldDelete(context Ctx, owner MetafieldOwner, id int) (err error) {
+	if err = owner.validate(); err != nil {
+		return
+	}
+
+	return r.Delete(context, owner.path(), id)
+}
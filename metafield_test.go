@@ -0,0 +1,89 @@
+package shopify
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestMetafieldListAndCreate(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/admin/api/2020-10/products/632910392/metafields.json" && req.URL.RawQuery == "key=warehouse&namespace=inventory":
+			_, _ = rw.Write([]byte(`{"metafields":[{"id":845366454,"namespace":"inventory","key":"warehouse","value":25,"value_type":"integer"}]}`))
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/metafields.json":
+			var raw map[string]map[string]interface{}
+			_ = json.NewDecoder(req.Body).Decode(&raw)
+			if raw["metafield"]["value"] != `{"size":"large"}` {
+				rw.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			rw.WriteHeader(http.StatusCreated)
+			_, _ = rw.Write([]byte(`{"metafield":{"id":721389482,"namespace":"settings","key":"sizing","value":"{\"size\":\"large\"}","value_type":"json_string","owner_resource":"shop"}}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	product := MetafieldOwner{Resource: MetafieldOwnerProduct, Id: 632910392}
+	metafields, _, err := client.MetafieldList(requestContext, product, MetafieldRequestOptions{Namespace: "inventory", Key: "warehouse"})
+	if err != nil || len(metafields) != 1 {
+		t.Fatalf("unexpected metafields %+v %v", metafields, err)
+	}
+	if value, err := metafields[0].Decode(); err != nil || value != int64(25) {
+		t.Errorf("unexpected value %#v %v", value, err)
+	}
+
+	request := Metafield{Namespace: "settings", Key: "sizing", ValueType: MetafieldValueTypeJsonString}
+	if err = request.SetValue(map[string]string{"size": "large"}); err != nil {
+		t.Fatal(err)
+	}
+	metafield, err := client.MetafieldCreate(requestContext, MetafieldOwner{Resource: MetafieldOwnerShop}, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sizing struct {
+		Size string `json:"size"`
+	}
+	if err = metafield.JsonValue(&sizing); err != nil || sizing.Size != "large" {
+		t.Errorf("unexpected json value %+v %v", sizing, err)
+	}
+
+	if _, _, err = client.MetafieldList(requestContext, MetafieldOwner{Resource: MetafieldOwnerOrder}, MetafieldRequestOptions{}); err == nil {
+		t.Error("expected an error for an order owner without an id")
+	}
+
+	iterator := client.MetafieldListIterator(requestContext, MetafieldOwner{Resource: MetafieldOwnerOrder}, MetafieldRequestOptions{})
+	if iterator.Next() || iterator.Err() == nil {
+		t.Error("expected the iterator to stop with an error for an order owner without an id")
+	}
+}
+
+func TestMetafieldTypedValues(t *testing.T) {
+	integer := Metafield{Type: MetafieldTypeNumberInteger}
+	if err := integer.SetValue(12); err != nil || string(integer.Value) != `"12"` {
+		t.Errorf("unexpected number_integer value %s %v", integer.Value, err)
+	}
+
+	legacy := Metafield{ValueType: MetafieldValueTypeInteger}
+	if err := legacy.SetValue(12); err != nil || string(legacy.Value) != `12` {
+		t.Errorf("unexpected integer value %s %v", legacy.Value, err)
+	}
+	if err := legacy.SetValue("twelve"); err == nil {
+		t.Error("expected an error for a non numeric integer value")
+	}
+
+	date := Metafield{Type: MetafieldTypeDate}
+	_ = date.SetValue(time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC))
+	if value, err := date.Decode(); err != nil || !value.(time.Time).Equal(time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected date value %v %v", value, err)
+	}
+
+	boolean := Metafield{Type: MetafieldTypeBoolean, Value: json.RawMessage(`"true"`)}
+	if value, err := boolean.Decode(); err != nil || value != true {
+		t.Errorf("unexpected boolean value %v %v", value, err)
+	}
+}
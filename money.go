@@ -0,0 +1,67 @@
+package shopify
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"regexp"
+)
+
+/*
+Money is a decimal amount kept as the text Shopify sent, such as "10.00", so no precision is lost to
+floats. Shopify sends amounts as strings in most places and as numbers in a few, both are accepted
+and Money is always sent as a string. Only plain decimals such as "-10", "10" or "10.5" are valid, an
+empty Money means the amount wasn't set.
+*/
+type Money string
+
+var moneyPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+func (m Money) String() string {
+	return string(m)
+}
+
+/*
+Reports whether the amount is empty or a plain decimal.
+*/
+func (m Money) Valid() bool {
+	return m == "" || moneyPattern.MatchString(string(m))
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	if !m.Valid() {
+		return nil, errors.Errorf("%q is not an amount of money", string(m))
+	}
+
+	return json.Marshal(string(m))
+}
+
+func (m *Money) UnmarshalJSON(data []byte) (err error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*m = ""
+		return
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err = json.Unmarshal(data, &text); err != nil {
+			return
+		}
+		if !Money(text).Valid() {
+			return errors.Errorf("%s is not an amount of money", data)
+		}
+		*m = Money(text)
+		return
+	}
+
+	var number json.Number
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(&number); err != nil || !Money(number).Valid() {
+		return errors.Errorf("%s is not an amount of money", data)
+	}
+
+	*m = Money(number)
+	return
+}
@@ -2,8 +2,21 @@ package shopify
 
 import (
 	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"github.com/pkg/errors"
 	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 )
 
 type OAuthResponse struct {
@@ -26,7 +39,18 @@ func (c *RestAdminClient) OAuthRequest(details Ctx, request OAuthRequest) (resul
 		return
 	}
 
-	resp, err := c.Http.Post(accessTokenRequestUrl, "application/json", bytes.NewBuffer(requestStr))
+	ctx := details.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", accessTokenRequestUrl, bytes.NewBuffer(requestStr))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Http.Do(req)
 	if err != nil {
 		return
 	}
@@ -34,6 +58,11 @@ func (c *RestAdminClient) OAuthRequest(details Ctx, request OAuthRequest) (resul
 	defer resp.Body.Close()
 
 	buf, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode >= 300 {
+		err = newShopifyError(Request{Method: "POST", Url: accessTokenRequestUrl}, resp, buf)
+		return
+	}
+
 	err = json.Unmarshal(buf, &result)
 	if err != nil {
 		return
@@ -41,3 +70,250 @@ func (c *RestAdminClient) OAuthRequest(details Ctx, request OAuthRequest) (resul
 
 	return
 }
+
+const (
+	shopDomainPattern = `^[a-zA-Z0-9][a-zA-Z0-9\-]*\.myshopify\.com$`
+	stateTtl          = 10 * time.Minute
+)
+
+var shopDomainRegexp = regexp.MustCompile(shopDomainPattern)
+
+/*
+StateStore keeps the state nonces handed out with authorize redirects so the callback can prove
+it belongs to an install we started. Consume must only succeed once for each saved state.
+*/
+type StateStore interface {
+	Save(shopName string, state string) error
+	Consume(shopName string, state string) bool
+}
+
+/*
+InstallFlow is an http.Handler for the OAuth app install flow. Requests without a code are treated
+as install requests and redirected to the shop's authorize page, requests with a code are treated as
+the callback from Shopify. The callback is only accepted after the state, the shop hostname and the
+hmac signature have been checked, the code is then exchanged with OAuthRequest and the response is
+handed to OnInstall.
+
+Mount the same InstallFlow on the install path and on the path of RedirectUri.
+*/
+type InstallFlow struct {
+	Client      *RestAdminClient
+	ApiKey      string
+	ApiSecret   string
+	Scopes      []string
+	RedirectUri string
+	// Requests an online access token bound to the user installing the app
+	PerUser bool
+	// Defaults to an in memory store, which only works when a single instance serves the install
+	States StateStore
+	// Called after the access token was issued, it is responsible for writing the response
+	OnInstall func(rw http.ResponseWriter, req *http.Request, shopName string, response OAuthResponse)
+	// Optional, replaces the default plain text error responses
+	OnError func(rw http.ResponseWriter, req *http.Request, status int, err error)
+
+	once   sync.Once
+	states StateStore
+}
+
+func (f *InstallFlow) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	shopName := query.Get("shop")
+	if !ValidShopDomain(shopName) {
+		f.fail(rw, req, http.StatusBadRequest, errors.Errorf("invalid shop domain %q", shopName))
+		return
+	}
+
+	if query.Get("hmac") != "" && !VerifyQueryHmac(query, f.ApiSecret) {
+		f.fail(rw, req, http.StatusUnauthorized, errors.New("invalid hmac signature"))
+		return
+	}
+
+	if query.Get("code") == "" {
+		f.install(rw, req, shopName)
+		return
+	}
+
+	f.callback(rw, req, shopName, query)
+}
+
+/*
+Builds the url of the shop's authorize page for the given state nonce.
+*/
+func (f *InstallFlow) AuthorizeUrl(shopName string, state string) string {
+	values := url.Values{}
+	values.Set("client_id", f.ApiKey)
+	values.Set("scope", strings.Join(f.Scopes, ","))
+	values.Set("redirect_uri", f.RedirectUri)
+	values.Set("state", state)
+	if f.PerUser {
+		values.Set("grant_options[]", "per-user")
+	}
+
+	return "https://" + shopName + "/admin/oauth/authorize?" + values.Encode()
+}
+
+func (f *InstallFlow) install(rw http.ResponseWriter, req *http.Request, shopName string) {
+	state, err := NewStateNonce()
+	if err != nil {
+		f.fail(rw, req, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = f.stateStore().Save(shopName, state)
+	if err != nil {
+		f.fail(rw, req, http.StatusInternalServerError, errors.WithMessage(err, "unable to save the oauth state"))
+		return
+	}
+
+	http.Redirect(rw, req, f.AuthorizeUrl(shopName, state), http.StatusFound)
+}
+
+func (f *InstallFlow) callback(rw http.ResponseWriter, req *http.Request, shopName string, query url.Values) {
+	if query.Get("hmac") == "" {
+		f.fail(rw, req, http.StatusUnauthorized, errors.New("the callback is missing the hmac signature"))
+		return
+	}
+
+	if !f.stateStore().Consume(shopName, query.Get("state")) {
+		f.fail(rw, req, http.StatusForbidden, errors.New("unknown or expired oauth state"))
+		return
+	}
+
+	details := Ctx{ShopName: shopName, Ctx: req.Context()}
+	response, err := f.Client.OAuthRequest(details, OAuthRequest{
+		ClientId:     f.ApiKey,
+		ClientSecret: f.ApiSecret,
+		Code:         query.Get("code"),
+	})
+	if err != nil {
+		f.fail(rw, req, http.StatusBadGateway, errors.WithMessage(err, "unable to exchange the oauth code"))
+		return
+	}
+
+	f.OnInstall(rw, req, shopName, response)
+}
+
+func (f *InstallFlow) stateStore() StateStore {
+	if f.States != nil {
+		return f.States
+	}
+
+	f.once.Do(func() {
+		f.states = NewMemoryStateStore(stateTtl)
+	})
+	return f.states
+}
+
+func (f *InstallFlow) fail(rw http.ResponseWriter, req *http.Request, status int, err error) {
+	if f.OnError != nil {
+		f.OnError(rw, req, status, err)
+		return
+	}
+
+	http.Error(rw, http.StatusText(status), status)
+}
+
+/*
+Checks that the shop is a bare *.myshopify.com hostname, which guards the token exchange
+against being pointed at another host.
+*/
+func ValidShopDomain(shopName string) bool {
+	return shopDomainRegexp.MatchString(shopName)
+}
+
+/*
+Verifies the hmac parameter Shopify adds to the install request and to the oauth callback.
+The message is every other parameter sorted by name and joined as name=value pairs with &.
+*/
+func VerifyQueryHmac(query url.Values, secret string) bool {
+	signature, err := hex.DecodeString(query.Get("hmac"))
+	if err != nil || len(signature) == 0 {
+		return false
+	}
+
+	return hmac.Equal(signature, queryHmac(query, secret))
+}
+
+func queryHmac(query url.Values, secret string) []byte {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		if key == "hmac" || key == "signature" {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, escapeHmacComponent(key, true)+"="+escapeHmacComponent(strings.Join(query[key], ","), false))
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strings.Join(pairs, "&")))
+	return mac.Sum(nil)
+}
+
+func escapeHmacComponent(value string, isKey bool) string {
+	value = strings.ReplaceAll(value, "%", "%25")
+	value = strings.ReplaceAll(value, "&", "%26")
+	if isKey {
+		value = strings.ReplaceAll(value, "=", "%3D")
+	}
+	return value
+}
+
+/*
+Creates a random hex encoded nonce suitable for the oauth state parameter.
+*/
+func NewStateNonce() (string, error) {
+	buf := make([]byte, 16)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", errors.WithMessage(err, "unable to generate the oauth state")
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+type memoryStateStore struct {
+	ttl    time.Duration
+	mu     sync.Mutex
+	states map[string]time.Time
+}
+
+/*
+Creates a StateStore that keeps states in memory and forgets them after the ttl.
+*/
+func NewMemoryStateStore(ttl time.Duration) StateStore {
+	return &memoryStateStore{
+		ttl:    ttl,
+		states: make(map[string]time.Time),
+	}
+}
+
+func (s *memoryStateStore) Save(shopName string, state string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, expires := range s.states {
+		if now.After(expires) {
+			delete(s.states, key)
+		}
+	}
+
+	s.states[shopName+"|"+state] = now.Add(s.ttl)
+	return nil
+}
+
+func (s *memoryStateStore) Consume(shopName string, state string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := shopName + "|" + state
+	expires, ok := s.states[key]
+	delete(s.states, key)
+
+	return ok && state != "" && time.Now().Before(expires)
+}
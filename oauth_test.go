@@ -0,0 +1,131 @@
+package shopify
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+)
+
+// Sends every request to the test server regardless of the shop hostname in the url.
+type rewriteHostTransport struct {
+	host string
+	base http.RoundTripper
+}
+
+func (t rewriteHostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Host = t.host
+	return t.base.RoundTrip(req)
+}
+
+func signQuery(query url.Values, secret string) url.Values {
+	query.Set("hmac", hex.EncodeToString(queryHmac(query, secret)))
+	return query
+}
+
+func TestVerifyQueryHmac(t *testing.T) {
+	// the example from the Shopify oauth documentation
+	query, _ := url.ParseQuery("code=0907a61c0c8d55e99db179b68161bc00&hmac=700e2dadb827fcc8609e9d5ce208b2e9cdaab9df07390d2cbca10d7c328fc4bf&shop=some-shop.myshopify.com&state=0.6784241404160823&timestamp=1337178173")
+	if !VerifyQueryHmac(query, "hush") {
+		t.Error("expected the documented signature to be valid")
+	}
+
+	query.Set("shop", "other-shop.myshopify.com")
+	if VerifyQueryHmac(query, "hush") {
+		t.Error("expected a tampered query to be rejected")
+	}
+}
+
+func TestValidShopDomain(t *testing.T) {
+	if !ValidShopDomain("some-shop.myshopify.com") {
+		t.Error("expected a myshopify domain to be valid")
+	}
+
+	for _, shop := range []string{"", "evil.com", "shop.myshopify.com.evil.com", "evil.com/shop.myshopify.com", "-shop.myshopify.com"} {
+		if ValidShopDomain(shop) {
+			t.Errorf("expected %q to be rejected", shop)
+		}
+	}
+}
+
+func TestInstallFlow(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var request OAuthRequest
+		_ = json.NewDecoder(req.Body).Decode(&request)
+		if req.URL.Path == "/admin/oauth/access_token" && req.Method == "POST" && request.Code == "thecode" && request.ClientSecret == "secret" {
+			_, _ = rw.Write([]byte(`{"access_token":"thisisatoken","scope":"read_products"}`))
+			return
+		}
+
+		rw.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	serverUrl, _ := url.Parse(server.URL)
+	httpClient := server.Client()
+	httpClient.Transport = rewriteHostTransport{host: serverUrl.Host, base: httpClient.Transport}
+
+	var installed OAuthResponse
+	flow := &InstallFlow{
+		Client: &RestAdminClient{
+			Http:    httpClient,
+			Logger:  log.New(os.Stdout, "", log.Ldate|log.Ltime|log.Lshortfile),
+			Version: VERSION_2019_07,
+		},
+		ApiKey:      "key",
+		ApiSecret:   "secret",
+		Scopes:      []string{"read_products", "write_products"},
+		RedirectUri: "https://app.example.com/callback",
+		OnInstall: func(rw http.ResponseWriter, req *http.Request, shopName string, response OAuthResponse) {
+			installed = response
+		},
+	}
+
+	recorder := httptest.NewRecorder()
+	flow.ServeHTTP(recorder, httptest.NewRequest("GET", "/install?shop=test.myshopify.com", nil))
+	if recorder.Code != http.StatusFound {
+		t.Fatalf("expected a redirect, got %v", recorder.Code)
+	}
+
+	redirect, _ := url.Parse(recorder.Header().Get("Location"))
+	state := redirect.Query().Get("state")
+	if redirect.Host != "test.myshopify.com" || redirect.Query().Get("scope") != "read_products,write_products" ||
+		redirect.Query().Get("redirect_uri") != "https://app.example.com/callback" || len(state) != 32 {
+		t.Errorf("unexpected authorize redirect %v", redirect)
+	}
+
+	callback := url.Values{}
+	callback.Set("code", "thecode")
+	callback.Set("shop", "test.myshopify.com")
+	callback.Set("state", "wrongstate")
+	callback.Set("timestamp", "1337178173")
+	recorder = httptest.NewRecorder()
+	flow.ServeHTTP(recorder, httptest.NewRequest("GET", "/callback?"+signQuery(callback, "secret").Encode(), nil))
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("expected an unknown state to be rejected, got %v", recorder.Code)
+	}
+
+	callback.Set("state", state)
+	recorder = httptest.NewRecorder()
+	flow.ServeHTTP(recorder, httptest.NewRequest("GET", "/callback?"+signQuery(callback, "othersecret").Encode(), nil))
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("expected a bad signature to be rejected, got %v", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	flow.ServeHTTP(recorder, httptest.NewRequest("GET", "/callback?"+signQuery(callback, "secret").Encode(), nil))
+	if installed.AccessToken != "thisisatoken" {
+		t.Errorf("expected the install callback to receive the token, got %+v status %v", installed, recorder.Code)
+	}
+
+	installed = OAuthResponse{}
+	recorder = httptest.NewRecorder()
+	flow.ServeHTTP(recorder, httptest.NewRequest("GET", "/callback?"+signQuery(callback, "secret").Encode(), nil))
+	if recorder.Code != http.StatusForbidden || installed.AccessToken != "" {
+		t.Errorf("expected a state to only be usable once, got %v", recorder.Code)
+	}
+}
@@ -0,0 +1,368 @@
+package shopify
+
+import (
+	"encoding/json"
+	"github.com/google/go-querystring/query"
+	"github.com/pkg/errors"
+	"strconv"
+)
+
+const (
+	OrderCreate    = "orders/create"
+	OrderUpdated   = "orders/updated"
+	OrderPaid      = "orders/paid"
+	OrderCancelled = "orders/cancelled"
+	OrderFulfilled = "orders/fulfilled"
+	OrderDelete    = "orders/delete"
+)
+
+type Order struct {
+	AppId                  int                   `json:"app_id,omitempty"`
+	BillingAddress         *Address              `json:"billing_address,omitempty"`
+	BrowserIp              string                `json:"browser_ip,omitempty"`
+	BuyerAcceptsMarketing  bool                  `json:"buyer_accepts_marketing,omitempty"`
+	CancelReason           string                `json:"cancel_reason,omitempty"`
+	CancelledAt            string                `json:"cancelled_at,omitempty"`
+	CartToken              string                `json:"cart_token,omitempty"`
+	CheckoutToken          string                `json:"checkout_token,omitempty"`
+	ClosedAt               string                `json:"closed_at,omitempty"`
+	Confirmed              bool                  `json:"confirmed,omitempty"`
+	ContactEmail           string                `json:"contact_email,omitempty"`
+	CreatedAt              string                `json:"created_at,omitempty"`
+	Currency               string                `json:"currency,omitempty"`
+	Customer               *Customer             `json:"customer,omitempty"`
+	CustomerLocale         string                `json:"customer_locale,omitempty"`
+	DiscountApplications   []DiscountApplication `json:"discount_applications,omitempty"`
+	DiscountCodes          []DiscountCode        `json:"discount_codes,omitempty"`
+	Email                  string                `json:"email,omitempty"`
+	FinancialStatus        string                `json:"financial_status,omitempty"`
+	Fulfillments           []Fulfillment         `json:"fulfillments,omitempty"`
+	FulfillmentStatus      string                `json:"fulfillment_status,omitempty"`
+	Gateway                string                `json:"gateway,omitempty"`
+	Id                     int                   `json:"id,omitempty"`
+	InventoryBehaviour     string                `json:"inventory_behaviour,omitempty"`
+	LandingSite            string                `json:"landing_site,omitempty"`
+	LineItems              []LineItem            `json:"line_items,omitempty"`
+	LocationId             int                   `json:"location_id,omitempty"`
+	Name                   string                `json:"name,omitempty"`
+	Note                   string                `json:"note,omitempty"`
+	NoteAttributes         []NoteAttribute       `json:"note_attributes,omitempty"`
+	Number                 int                   `json:"number,omitempty"`
+	OrderNumber            int                   `json:"order_number,omitempty"`
+	OrderStatusUrl         string                `json:"order_status_url,omitempty"`
+	PaymentGatewayNames    []string              `json:"payment_gateway_names,omitempty"`
+	Phone                  string                `json:"phone,omitempty"`
+	PresentmentCurrency    string                `json:"presentment_currency,omitempty"`
+	ProcessedAt            string                `json:"processed_at,omitempty"`
+	ProcessingMethod       string                `json:"processing_method,omitempty"`
+	ReferringSite          string                `json:"referring_site,omitempty"`
+	Refunds                []Refund              `json:"refunds,omitempty"`
+	SendFulfillmentReceipt bool                  `json:"send_fulfillment_receipt,omitempty"`
+	SendReceipt            bool                  `json:"send_receipt,omitempty"`
+	ShippingAddress        *Address              `json:"shipping_address,omitempty"`
+	ShippingLines          []ShippingLine        `json:"shipping_lines,omitempty"`
+	SourceName             string                `json:"source_name,omitempty"`
+	SubtotalPrice          Money                 `json:"subtotal_price,omitempty"`
+	SubtotalPriceSet       *PriceSet             `json:"subtotal_price_set,omitempty"`
+	Tags                   string                `json:"tags,omitempty"`
+	TaxLines               []TaxLine             `json:"tax_lines,omitempty"`
+	TaxesIncluded          bool                  `json:"taxes_included,omitempty"`
+	Test                   bool                  `json:"test,omitempty"`
+	Token                  string                `json:"token,omitempty"`
+	TotalDiscounts         Money                 `json:"total_discounts,omitempty"`
+	TotalDiscountsSet      *PriceSet             `json:"total_discounts_set,omitempty"`
+	TotalLineItemsPrice    Money                 `json:"total_line_items_price,omitempty"`
+	TotalLineItemsPriceSet *PriceSet             `json:"total_line_items_price_set,omitempty"`
+	TotalPrice             Money                 `json:"total_price,omitempty"`
+	TotalPriceSet          *PriceSet             `json:"total_price_set,omitempty"`
+	TotalTax               Money                 `json:"total_tax,omitempty"`
+	TotalTaxSet            *PriceSet             `json:"total_tax_set,omitempty"`
+	TotalWeight            int                   `json:"total_weight,omitempty"`
+	UpdatedAt              string                `json:"updated_at,omitempty"`
+	UserId                 int                   `json:"user_id,omitempty"`
+}
+
+type LineItem struct {
+	DiscountAllocations        []DiscountAllocation `json:"discount_allocations,omitempty"`
+	FulfillableQuantity        int                  `json:"fulfillable_quantity,omitempty"`
+	FulfillmentService         string               `json:"fulfillment_service,omitempty"`
+	FulfillmentStatus          string               `json:"fulfillment_status,omitempty"`
+	GiftCard                   bool                 `json:"gift_card,omitempty"`
+	Grams                      int                  `json:"grams,omitempty"`
+	Id                         int                  `json:"id,omitempty"`
+	Name                       string               `json:"name,omitempty"`
+	Price                      Money                `json:"price,omitempty"`
+	PriceSet                   *PriceSet            `json:"price_set,omitempty"`
+	ProductExists              bool                 `json:"product_exists,omitempty"`
+	ProductId                  int                  `json:"product_id,omitempty"`
+	Properties                 []NoteAttribute      `json:"properties,omitempty"`
+	Quantity                   int                  `json:"quantity,omitempty"`
+	RequiresShipping           bool                 `json:"requires_shipping,omitempty"`
+	Sku                        string               `json:"sku,omitempty"`
+	Taxable                    bool                 `json:"taxable,omitempty"`
+	TaxLines                   []TaxLine            `json:"tax_lines,omitempty"`
+	Title                      string               `json:"title,omitempty"`
+	TotalDiscount              Money                `json:"total_discount,omitempty"`
+	TotalDiscountSet           *PriceSet            `json:"total_discount_set,omitempty"`
+	VariantId                  int                  `json:"variant_id,omitempty"`
+	VariantInventoryManagement string               `json:"variant_inventory_management,omitempty"`
+	VariantTitle               string               `json:"variant_title,omitempty"`
+	Vendor                     string               `json:"vendor,omitempty"`
+}
+
+type ShippingLine struct {
+	CarrierIdentifier             string               `json:"carrier_identifier,omitempty"`
+	Code                          string               `json:"code,omitempty"`
+	DeliveryCategory              string               `json:"delivery_category,omitempty"`
+	DiscountAllocations           []DiscountAllocation `json:"discount_allocations,omitempty"`
+	DiscountedPrice               Money                `json:"discounted_price,omitempty"`
+	DiscountedPriceSet            *PriceSet            `json:"discounted_price_set,omitempty"`
+	Id                            int                  `json:"id,omitempty"`
+	Phone                         string               `json:"phone,omitempty"`
+	Price                         Money                `json:"price,omitempty"`
+	PriceSet                      *PriceSet            `json:"price_set,omitempty"`
+	RequestedFulfillmentServiceId string               `json:"requested_fulfillment_service_id,omitempty"`
+	Source                        string               `json:"source,omitempty"`
+	TaxLines                      []TaxLine            `json:"tax_lines,omitempty"`
+	Title                         string               `json:"title,omitempty"`
+}
+
+type TaxLine struct {
+	Price    Money     `json:"price,omitempty"`
+	PriceSet *PriceSet `json:"price_set,omitempty"`
+	Rate     float64   `json:"rate,omitempty"`
+	Title    string    `json:"title,omitempty"`
+}
+
+type DiscountApplication struct {
+	AllocationMethod string `json:"allocation_method,omitempty"`
+	Code             string `json:"code,omitempty"`
+	Description      string `json:"description,omitempty"`
+	TargetSelection  string `json:"target_selection,omitempty"`
+	TargetType       string `json:"target_type,omitempty"`
+	Title            string `json:"title,omitempty"`
+	Type             string `json:"type,omitempty"`
+	Value            Money  `json:"value,omitempty"`
+	ValueType        string `json:"value_type,omitempty"`
+}
+
+type DiscountAllocation struct {
+	Amount                   Money     `json:"amount,omitempty"`
+	AmountSet                *PriceSet `json:"amount_set,omitempty"`
+	DiscountApplicationIndex int       `json:"discount_application_index"`
+}
+
+type DiscountCode struct {
+	Amount Money  `json:"amount,omitempty"`
+	Code   string `json:"code,omitempty"`
+	Type   string `json:"type,omitempty"`
+}
+
+type NoteAttribute struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+/*
+An amount in the shop currency and in the currency the customer was presented with.
+*/
+type PriceSet struct {
+	ShopMoney        Price `json:"shop_money"`
+	PresentmentMoney Price `json:"presentment_money"`
+}
+
+type Address struct {
+	Address1     string  `json:"address1,omitempty"`
+	Address2     string  `json:"address2,omitempty"`
+	City         string  `json:"city,omitempty"`
+	Company      string  `json:"company,omitempty"`
+	Country      string  `json:"country,omitempty"`
+	CountryCode  string  `json:"country_code,omitempty"`
+	FirstName    string  `json:"first_name,omitempty"`
+	LastName     string  `json:"last_name,omitempty"`
+	Latitude     float64 `json:"latitude,omitempty"`
+	Longitude    float64 `json:"longitude,omitempty"`
+	Name         string  `json:"name,omitempty"`
+	Phone        string  `json:"phone,omitempty"`
+	Province     string  `json:"province,omitempty"`
+	ProvinceCode string  `json:"province_code,omitempty"`
+	Zip          string  `json:"zip,omitempty"`
+}
+
+type OrderWrapper struct {
+	Order *Order `json:"order"`
+}
+
+func (o OrderWrapper) GetResourceName() string {
+	return "orders"
+}
+
+func (o OrderWrapper) GetId() int {
+	return o.Order.Id
+}
+
+func (o OrderWrapper) BuildGetUrl(request Request) string {
+	return BuildIdUrl(request, o.GetResourceName(), o.GetId())
+}
+
+func (o OrderWrapper) BuildCreateUrl(request Request) string {
+	return BuildSimpleUrl(request, o.GetResourceName())
+}
+
+func (o OrderWrapper) BuildUpdateUrl(request Request) string {
+	return BuildIdUrl(request, o.GetResourceName(), o.GetId())
+}
+
+type Orders struct {
+	Orders []Order `json:"orders"`
+}
+
+type OrdersWrapper struct {
+	Orders []Order
+}
+
+func (o *OrdersWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper Orders
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return
+	}
+
+	o.Orders = append(o.Orders, wrapper.Orders...)
+	return
+}
+
+func (o OrdersWrapper) GetResourceName() string {
+	return "orders"
+}
+
+type OrderRequestOptions struct {
+	AttributionAppId  string   `url:"attribution_app_id,omitempty"`
+	CreatedAtMax      string   `url:"created_at_max,omitempty"`
+	CreatedAtMin      string   `url:"created_at_min,omitempty"`
+	Fields            []string `url:"fields,omitempty,comma"`
+	FinancialStatus   string   `url:"financial_status,omitempty"`
+	FulfillmentStatus string   `url:"fulfillment_status,omitempty"`
+	Ids               []int    `url:"ids,omitempty,comma"`
+	Limit             int      `url:"limit,omitempty"`
+	ProcessedAtMax    string   `url:"processed_at_max,omitempty"`
+	ProcessedAtMin    string   `url:"processed_at_min,omitempty"`
+	SinceId           int      `url:"since_id,omitempty"`
+	Status            string   `url:"status,omitempty"`
+	UpdatedAtMax      string   `url:"updated_at_max,omitempty"`
+	UpdatedAtMin      string   `url:"updated_at_min,omitempty"`
+}
+
+func (o OrderRequestOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(o)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", o)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
+}
+
+/*
+The options for cancelling an order. Reason is one of customer, fraud, inventory, declined or other.
+*/
+type OrderCancelOptions struct {
+	Amount   Money  `json:"amount,omitempty"`
+	Currency string `json:"currency,omitempty"`
+	Email    bool   `json:"email,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	Restock  bool   `json:"restock,omitempty"`
+}
+
+func (r *RestAdminClient) OrderList(context Ctx, options OrderRequestOptions) (results []Order, next string, err error) {
+	var wrapper = &OrdersWrapper{}
+	next, err = r.List(context, options, wrapper)
+	results = wrapper.Orders
+	return
+}
+
+type OrderIterator struct {
+	*Iterator
+}
+
+/*
+The orders on the current page.
+*/
+func (i *OrderIterator) Orders() []Order {
+	if i.Page() == nil {
+		return nil
+	}
+
+	return i.Page().(*OrdersWrapper).Orders
+}
+
+func (r *RestAdminClient) OrderListIterator(context Ctx, options OrderRequestOptions) *OrderIterator {
+	return &OrderIterator{r.NewIterator(context, options, func() Lister { return &OrdersWrapper{} })}
+}
+
+func (r *RestAdminClient) OrderGet(context Ctx, id int) (result *Order, err error) {
+	wrapper := &OrderWrapper{Order: &Order{Id: id}}
+	err = r.Get(context, wrapper)
+	result = wrapper.Order
+
+	return
+}
+
+/*
+Counts the orders matching the status and date filters of the options, the paging options are ignored.
+*/
+func (r *RestAdminClient) OrderCount(context Ctx, options OrderRequestOptions) (count int, err error) {
+	return r.Count(context, "orders", options)
+}
+
+func (r *RestAdminClient) OrderCreate(context Ctx, request Order) (result *Order, err error) {
+	var returnWrapper = new(OrderWrapper)
+	err = r.Create(context, returnWrapper, OrderWrapper{Order: &request})
+	result = returnWrapper.Order
+
+	return
+}
+
+func (r *RestAdminClient) OrderUpdate(context Ctx, request Order) (result *Order, err error) {
+	var returnWrapper = new(OrderWrapper)
+	err = r.Update(context, returnWrapper, OrderWrapper{Order: &request})
+	result = returnWrapper.Order
+
+	return
+}
+
+func (r *RestAdminClient) OrderDelete(context Ctx, id int) (err error) {
+	return r.Delete(context, "orders", id)
+}
+
+func (r *RestAdminClient) OrderClose(context Ctx, id int) (result *Order, err error) {
+	return r.orderAction(context, id, "close", nil)
+}
+
+func (r *RestAdminClient) OrderOpen(context Ctx, id int) (result *Order, err error) {
+	return r.orderAction(context, id, "open", nil)
+}
+
+func (r *RestAdminClient) OrderCancel(context Ctx, id int, options OrderCancelOptions) (result *Order, err error) {
+	return r.orderAction(context, id, "cancel", options)
+}
+
+func (r *RestAdminClient) orderAction(context Ctx, id int, action string, body interface{}) (result *Order, err error) {
+	if body == nil {
+		body = struct{}{}
+	}
+
+	var wrapper OrderWrapper
+	err = r.Send(context, "POST", orderPath(id)+"/"+action, nil, body, &wrapper)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to %v order %v", action, id)
+		return
+	}
+	result = wrapper.Order
+
+	return
+}
+
+func orderPath(id int) string {
+	return "orders/" + strconv.Itoa(id)
+}
@@ -0,0 +1,82 @@
+package shopify
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestOrderListFilters(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/admin/api/2020-10/orders.json" &&
+			req.URL.RawQuery == "created_at_min=2020-01-01T00%3A00%3A00Z&financial_status=paid&limit=50&status=any" {
+			_, _ = rw.Write([]byte(`{"orders":[{"id":450789469,"line_items":[{"id":466157049,"quantity":1,"price":"199.00"}],"total_price_set":{"shop_money":{"amount":"199.00","currency_code":"USD"}}}]}`))
+			return
+		}
+
+		if req.URL.Path == "/admin/api/2020-10/orders/count.json" && req.URL.RawQuery == "status=open" {
+			_, _ = rw.Write([]byte(`{"count":7}`))
+			return
+		}
+
+		rw.WriteHeader(http.StatusNotFound)
+	})
+	defer server.Close()
+
+	orders, _, err := client.OrderList(requestContext, OrderRequestOptions{
+		Status:          "any",
+		FinancialStatus: "paid",
+		CreatedAtMin:    "2020-01-01T00:00:00Z",
+		Limit:           50,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 1 || orders[0].LineItems[0].Price != "199.00" || orders[0].TotalPriceSet.ShopMoney.Amount != "199.00" {
+		t.Errorf("unexpected orders %+v", orders)
+	}
+
+	count, err := client.OrderCount(requestContext, OrderRequestOptions{Status: "open"})
+	if err != nil || count != 7 {
+		t.Errorf("unexpected count %v %v", count, err)
+	}
+}
+
+func TestOrderGetAndCancel(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "GET" && req.URL.Path == "/admin/api/2020-10/orders/450789469.json" {
+			_, _ = rw.Write([]byte(`{"order":{"id":450789469,"name":"#1001"}}`))
+			return
+		}
+
+		if req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/orders/450789469/cancel.json" {
+			var options OrderCancelOptions
+			_ = json.NewDecoder(req.Body).Decode(&options)
+			if options.Reason != "customer" {
+				rw.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			_, _ = rw.Write([]byte(`{"order":{"id":450789469,"cancel_reason":"customer","cancelled_at":"2020-10-01T12:00:00-04:00"}}`))
+			return
+		}
+
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = rw.Write([]byte(`{"errors":"Not Found"}`))
+	})
+	defer server.Close()
+
+	order, err := client.OrderGet(requestContext, 450789469)
+	if err != nil || order.Name != "#1001" {
+		t.Errorf("unexpected order %+v %v", order, err)
+	}
+
+	order, err = client.OrderCancel(requestContext, 450789469, OrderCancelOptions{Reason: "customer"})
+	if err != nil || order.CancelReason != "customer" {
+		t.Errorf("unexpected cancelled order %+v %v", order, err)
+	}
+
+	_, err = client.OrderClose(requestContext, 1)
+	if !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
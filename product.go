@@ -3,8 +3,7 @@ package shopify
 import (
 	"encoding/json"
 	"github.com/google/go-querystring/query"
-	"io/ioutil"
-	"net/http"
+	"github.com/pkg/errors"
 )
 
 type Product struct {
@@ -23,39 +22,69 @@ type Product struct {
 	TemplateSuffix                 string           `json:"template_suffix,omitempty"`
 	MetafieldsGlobalTitleTag       string           `json:"metafields_global_title_tag,omitempty"`
 	MetafieldsGlobalDescriptionTag string           `json:"metafields_global_description_tag,omitempty"`
-	UpdateAt                       string           `json:"update_at,omitempty"`
+	UpdatedAt                      string           `json:"updated_at,omitempty"`
 	Vendor                         string           `json:"vendor,omitempty"`
-	Image                          ProductImage     `json:"image,omitempty"`
+	Image                          *ProductImage    `json:"image,omitempty"`
 }
 
 type ProductOption struct {
-	Id        int      `json:"id"`
-	ProductId int      `json:"product_id"`
+	Id        int      `json:"id,omitempty"`
+	ProductId int      `json:"product_id,omitempty"`
 	Name      string   `json:"name"`
-	Position  int      `json:"position"`
-	Values    []string `json:"values"`
+	Position  int      `json:"position,omitempty"`
+	Values    []string `json:"values,omitempty"`
 }
 
-type ProductImage struct {
-	Id         int    `json:"id"`
-	ProductId  int    `json:"product_id"`
-	Position   int    `json:"position"`
-	CreatedAt  string `json:"created_at"`
-	UpdatedAt  string `json:"updated_at"`
-	Width      int    `json:"width"`
-	Height     int    `json:"height"`
-	Src        string `json:"src"`
-	VariantIds []int  `json:"variant_ids"`
+type ProductWrapper struct {
+	Product *Product `json:"product"`
 }
 
-type ProductWrapper struct {
+func (p ProductWrapper) GetResourceName() string {
+	return "products"
+}
+
+func (p ProductWrapper) GetId() int {
+	return p.Product.Id
+}
+
+func (p ProductWrapper) BuildGetUrl(request Request) string {
+	return BuildIdUrl(request, p.GetResourceName(), p.GetId())
+}
+
+func (p ProductWrapper) BuildCreateUrl(request Request) string {
+	return BuildSimpleUrl(request, p.GetResourceName())
+}
+
+func (p ProductWrapper) BuildUpdateUrl(request Request) string {
+	return BuildIdUrl(request, p.GetResourceName(), p.GetId())
+}
+
+type Products struct {
 	Products []Product `json:"products"`
 }
 
+type ProductsWrapper struct {
+	Products []Product
+}
+
+func (p *ProductsWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper Products
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return
+	}
+
+	p.Products = append(p.Products, wrapper.Products...)
+	return
+}
+
+func (p ProductsWrapper) GetResourceName() string {
+	return "products"
+}
+
 type ProductRequestOptions struct {
-	Ids                   []int    `url:"ids,omitempty"`
+	Ids                   []int    `url:"ids,omitempty,comma"`
 	Limit                 int      `url:"limit,omitempty"`
-	SinceId               int      `url:"since_id"`
+	SinceId               int      `url:"since_id,omitempty"`
 	Title                 string   `url:"title,omitempty"`
 	Vendor                string   `url:"vendor,omitempty"`
 	Handle                string   `url:"handle,omitempty"`
@@ -68,50 +97,88 @@ type ProductRequestOptions struct {
 	PublishedAtMin        string   `url:"published_at_min,omitempty"`
 	PublishedAtMax        string   `url:"published_at_max,omitempty"`
 	PublishedStatus       string   `url:"published_status,omitempty"`
-	Fields                []string `url:"fields,omitempty"`
+	Fields                []string `url:"fields,omitempty,comma"`
 	PresentmentCurrencies string   `url:"presentment_currencies,omitempty"`
-	All                   bool
+	// Deprecated: set AutoPaginate on the Ctx instead, it is kept as an alias for it.
+	All bool `url:"-"`
 }
 
-func (c *RestAdminClient) ProductList(details Ctx, options ProductRequestOptions) (products []Product, err error) {
-	v, err := query.Values(options)
+func (p ProductRequestOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(p)
 	if err != nil {
-		c.Logger.Println("there's an issue setting up the query params")
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", p)
 		return
 	}
-	requestUrl := "https://" + details.ShopName + "/admin/products.json?" + v.Encode()
-	c.Logger.Println(requestUrl)
 
-	req, err := http.NewRequest("GET", requestUrl, nil)
-	if err != nil {
-		return
+	queryParams = values.Encode()
+	return
+}
+
+func (r *RestAdminClient) ProductList(context Ctx, options ProductRequestOptions) (results []Product, next string, err error) {
+	if options.All {
+		context.AutoPaginate = true
 	}
 
-	req.Header.Add("X-Shopify-Access-Token", details.AccessToken)
+	var wrapper = &ProductsWrapper{}
+	next, err = r.List(context, options, wrapper)
+	results = wrapper.Products
+	return
+}
 
-	resp, err := c.Http.Do(req)
-	if err != nil {
-		return
-	}
+type ProductIterator struct {
+	*Iterator
+}
 
-	buf, _ := ioutil.ReadAll(resp.Body)
-	c.Logger.Println("This is the response for the products: ", string(buf))
-	wrapper := ProductWrapper{}
-	err = json.Unmarshal(buf, &wrapper)
-	if err != nil {
-		return
+/*
+The products on the current page.
+*/
+func (i *ProductIterator) Products() []Product {
+	if i.Page() == nil {
+		return nil
 	}
 
-	products = wrapper.Products
+	return i.Page().(*ProductsWrapper).Products
+}
 
-	if len(products) < options.Limit || !options.All || (options.Limit == 0 && len(products) < 50) {
-		return
-	}
+func (r *RestAdminClient) ProductListIterator(context Ctx, options ProductRequestOptions) *ProductIterator {
+	return &ProductIterator{r.NewIterator(context, options, func() Lister { return &ProductsWrapper{} })}
+}
+
+func (r *RestAdminClient) ProductGet(context Ctx, id int) (result *Product, err error) {
+	wrapper := &ProductWrapper{Product: &Product{Id: id}}
+	err = r.Get(context, wrapper)
+	result = wrapper.Product
+
+	return
+}
+
+/*
+Counts the products matching the filters of the options, the paging options are ignored.
+*/
+func (r *RestAdminClient) ProductCount(context Ctx, options ProductRequestOptions) (count int, err error) {
+	return r.Count(context, "products", options)
+}
+
+func (r *RestAdminClient) ProductCreate(context Ctx, request Product) (result *Product, err error) {
+	var returnWrapper = new(ProductWrapper)
+	err = r.Create(context, returnWrapper, ProductWrapper{Product: &request})
+	result = returnWrapper.Product
 
-	options.SinceId = products[len(products)-1].Id
-	nextResult, err := c.ProductList(details, options)
+	return
+}
 
-	products = append(products, nextResult...)
+/*
+Updates the product, only the fields that are set are sent. Including Variants or Images replaces
+all of them, leave them empty to keep the existing ones.
+*/
+func (r *RestAdminClient) ProductUpdate(context Ctx, request Product) (result *Product, err error) {
+	var returnWrapper = new(ProductWrapper)
+	err = r.Update(context, returnWrapper, ProductWrapper{Product: &request})
+	result = returnWrapper.Product
 
 	return
 }
+
+func (r *RestAdminClient) ProductDelete(context Ctx, id int) (err error) {
+	return r.Delete(context, "products", id)
+}
@@ -0,0 +1,160 @@
+package shopify
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/google/go-querystring/query"
+	"github.com/pkg/errors"
+)
+
+/*
+An image is created either from Src, a url Shopify downloads the image from, or from Attachment,
+the base64 encoded image. Data can be set to the raw image bytes instead of Attachment and the
+client encodes it when the image is created.
+
+Alt is a pointer so that String("") can clear the alt text. VariantIds is left out when nil, an empty
+non nil slice is sent and detaches the image from every variant.
+*/
+type ProductImage struct {
+	Id         int     `json:"id,omitempty"`
+	ProductId  int     `json:"product_id,omitempty"`
+	Position   int     `json:"position,omitempty"`
+	CreatedAt  string  `json:"created_at,omitempty"`
+	UpdatedAt  string  `json:"updated_at,omitempty"`
+	Alt        *string `json:"alt,omitempty"`
+	Width      int     `json:"width,omitempty"`
+	Height     int     `json:"height,omitempty"`
+	Src        string  `json:"src,omitempty"`
+	Attachment string  `json:"attachment,omitempty"`
+	Filename   string  `json:"filename,omitempty"`
+	VariantIds []int   `json:"variant_ids,omitempty"`
+	Data       []byte  `json:"-"`
+}
+
+func (p ProductImage) MarshalJSON() ([]byte, error) {
+	type image ProductImage
+	var variantIds *[]int
+	if p.VariantIds != nil {
+		variantIds = &p.VariantIds
+	}
+
+	return json.Marshal(struct {
+		image
+		VariantIds *[]int `json:"variant_ids,omitempty"`
+	}{image(p), variantIds})
+}
+
+type ProductImageWrapper struct {
+	Image *ProductImage `json:"image"`
+}
+
+func (p ProductImageWrapper) GetResourceName() string {
+	return productPath(p.Image.ProductId) + "/images"
+}
+
+func (p ProductImageWrapper) GetId() int {
+	return p.Image.Id
+}
+
+func (p ProductImageWrapper) BuildGetUrl(request Request) string {
+	return BuildIdUrl(request, p.GetResourceName(), p.GetId())
+}
+
+func (p ProductImageWrapper) BuildCreateUrl(request Request) string {
+	return BuildSimpleUrl(request, p.GetResourceName())
+}
+
+func (p ProductImageWrapper) BuildUpdateUrl(request Request) string {
+	return BuildIdUrl(request, p.GetResourceName(), p.GetId())
+}
+
+type ProductImages struct {
+	Images []ProductImage `json:"images"`
+}
+
+type ProductImagesWrapper struct {
+	ProductId int
+	Images    []ProductImage
+}
+
+func (p *ProductImagesWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper ProductImages
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return
+	}
+
+	p.Images = append(p.Images, wrapper.Images...)
+	return
+}
+
+func (p ProductImagesWrapper) GetResourceName() string {
+	return productPath(p.ProductId) + "/images"
+}
+
+type ProductImageRequestOptions struct {
+	Fields  []string `url:"fields,omitempty,comma"`
+	SinceId int      `url:"since_id,omitempty"`
+}
+
+func (p ProductImageRequestOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(p)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", p)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
+}
+
+func (r *RestAdminClient) ProductImageList(context Ctx, productId int, options ProductImageRequestOptions) (results []ProductImage, err error) {
+	var wrapper = &ProductImagesWrapper{ProductId: productId}
+	_, err = r.List(context, options, wrapper)
+	results = wrapper.Images
+	return
+}
+
+func (r *RestAdminClient) ProductImageGet(context Ctx, productId int, id int) (result *ProductImage, err error) {
+	wrapper := &ProductImageWrapper{Image: &ProductImage{ProductId: productId, Id: id}}
+	err = r.Get(context, wrapper)
+	result = wrapper.Image
+
+	return
+}
+
+func (r *RestAdminClient) ProductImageCount(context Ctx, productId int, options ProductImageRequestOptions) (count int, err error) {
+	return r.Count(context, productPath(productId)+"/images", options)
+}
+
+/*
+Adds an image to the product from request.Src, request.Attachment or the raw bytes in request.Data.
+*/
+func (r *RestAdminClient) ProductImageCreate(context Ctx, productId int, request ProductImage) (result *ProductImage, err error) {
+	request.ProductId = productId
+	if len(request.Data) > 0 {
+		request.Attachment = base64.StdEncoding.EncodeToString(request.Data)
+	}
+	if request.Src == "" && request.Attachment == "" {
+		err = errors.New("a product image needs a src, an attachment or data to be created")
+		return
+	}
+
+	var returnWrapper = new(ProductImageWrapper)
+	err = r.Create(context, returnWrapper, ProductImageWrapper{Image: &request})
+	result = returnWrapper.Image
+
+	return
+}
+
+func (r *RestAdminClient) ProductImageUpdate(context Ctx, productId int, request ProductImage) (result *ProductImage, err error) {
+	request.ProductId = productId
+	var returnWrapper = new(ProductImageWrapper)
+	err = r.Update(context, returnWrapper, ProductImageWrapper{Image: &request})
+	result = returnWrapper.Image
+
+	return
+}
+
+func (r *RestAdminClient) ProductImageDelete(context Ctx, productId int, id int) (err error) {
+	return r.Delete(context, productPath(productId)+"/images", id)
+}
@@ -0,0 +1,75 @@
+package shopify
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestProductImageCreateFromBytes(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != "POST" || req.URL.Path != "/admin/api/2020-10/products/632910392/images.json" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var wrapper ProductImageWrapper
+		_ = json.NewDecoder(req.Body).Decode(&wrapper)
+		if wrapper.Image.Attachment != "R0lGODlh" || *wrapper.Image.Alt != "front" || len(wrapper.Image.VariantIds) != 1 {
+			rw.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = rw.Write([]byte(`{"image":{"id":850703190,"product_id":632910392,"alt":"front","variant_ids":[808950810],"src":"https://cdn.shopify.com/s/files/1/0006/9093/3842/products/front.gif"}}`))
+	})
+	defer server.Close()
+
+	image, err := client.ProductImageCreate(requestContext, 632910392, ProductImage{
+		Alt:        String("front"),
+		Data:       []byte("GIF89a"),
+		VariantIds: []int{808950810},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if image.Id != 850703190 || image.Src == "" || image.VariantIds[0] != 808950810 {
+		t.Errorf("unexpected image %+v", image)
+	}
+
+	if _, err = client.ProductImageCreate(requestContext, 632910392, ProductImage{Alt: String("empty")}); err == nil {
+		t.Error("expected an error for an image without a source")
+	}
+}
+
+func TestProductImageUpdateClearsAltAndVariants(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != "PUT" || req.URL.Path != "/admin/api/2020-10/products/632910392/images/850703190.json" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var raw map[string]map[string]json.RawMessage
+		_ = json.NewDecoder(req.Body).Decode(&raw)
+		if string(raw["image"]["alt"]) != `""` || string(raw["image"]["variant_ids"]) != `[]` {
+			rw.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`{"image":{"id":850703190,"product_id":632910392,"alt":null,"variant_ids":[]}}`))
+	})
+	defer server.Close()
+
+	image, err := client.ProductImageUpdate(requestContext, 632910392, ProductImage{Id: 850703190, Alt: String(""), VariantIds: []int{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if image.Alt != nil || len(image.VariantIds) != 0 {
+		t.Errorf("unexpected image %+v", image)
+	}
+
+	buf, _ := json.Marshal(ProductImage{Id: 850703190, Position: 2})
+	if string(buf) != `{"id":850703190,"position":2}` {
+		t.Errorf("expected unset fields to be left out, got %s", buf)
+	}
+}
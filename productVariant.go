@@ -1,9 +1,20 @@
 package shopify
 
+import (
+	"encoding/json"
+	"github.com/google/go-querystring/query"
+	"github.com/pkg/errors"
+	"strconv"
+)
+
+/*
+RequiresShipping and Taxable are pointers so that false can be sent, for example Taxable: Bool(false)
+for a variant that isn't taxed. They are left out when nil.
+*/
 type ProductVariant struct {
 	Barcode             string             `json:"barcode,omitempty"`
 	CompareAtPrice      string             `json:"compare_at_price,omitempty"`
-	CreateAt            string             `json:"create_at,omitempty"`
+	CreatedAt           string             `json:"created_at,omitempty"`
 	FulfillmentService  string             `json:"fulfillment_service,omitempty"`
 	Grams               int                `json:"grams,omitempty"`
 	Id                  int                `json:"id,omitempty"`
@@ -20,8 +31,9 @@ type ProductVariant struct {
 	Position            int                `json:"position,omitempty"`
 	Price               string             `json:"price,omitempty"`
 	ProductId           int                `json:"product_id,omitempty"`
+	RequiresShipping    *bool              `json:"requires_shipping,omitempty"`
 	Sku                 string             `json:"sku,omitempty"`
-	Taxable             bool               `json:"taxable,omitempty"`
+	Taxable             *bool              `json:"taxable,omitempty"`
 	TaxCode             string             `json:"tax_code,omitempty"`
 	Title               string             `json:"title,omitempty"`
 	UpdatedAt           string             `json:"updated_at,omitempty"`
@@ -29,14 +41,17 @@ type ProductVariant struct {
 	WeightUnit          string             `json:"weight_unit,omitempty"`
 }
 
+/*
+CompareAtPrice is nil when the variant has no compare at price in that currency.
+*/
 type PresentmentPrice struct {
-	Price          Price `json:"price"`
-	CompareAtPrice Price `json:"compare_at_price"`
+	Price          Price  `json:"price"`
+	CompareAtPrice *Price `json:"compare_at_price"`
 }
 
 type Price struct {
 	CurrencyCode string `json:"currency_code"`
-	Amount       string `json:"amount"`
+	Amount       Money  `json:"amount"`
 }
 
 type ProductMetafield struct {
@@ -46,3 +61,174 @@ type ProductMetafield struct {
 	Namespace   string `json:"namespace"`
 	Description string `json:"description,omitempty"`
 }
+
+type VariantWrapper struct {
+	Variant *ProductVariant `json:"variant"`
+}
+
+func (v VariantWrapper) GetResourceName() string {
+	return "variants"
+}
+
+func (v VariantWrapper) GetId() int {
+	return v.Variant.Id
+}
+
+func (v VariantWrapper) BuildGetUrl(request Request) string {
+	return BuildIdUrl(request, v.GetResourceName(), v.GetId())
+}
+
+func (v VariantWrapper) BuildCreateUrl(request Request) string {
+	return BuildSimpleUrl(request, productPath(v.Variant.ProductId)+"/variants")
+}
+
+func (v VariantWrapper) BuildUpdateUrl(request Request) string {
+	return BuildIdUrl(request, v.GetResourceName(), v.GetId())
+}
+
+type Variants struct {
+	Variants []ProductVariant `json:"variants"`
+}
+
+type VariantsWrapper struct {
+	ProductId int
+	Variants  []ProductVariant
+}
+
+func (v *VariantsWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper Variants
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return
+	}
+
+	v.Variants = append(v.Variants, wrapper.Variants...)
+	return
+}
+
+func (v VariantsWrapper) GetResourceName() string {
+	return productPath(v.ProductId) + "/variants"
+}
+
+type VariantRequestOptions struct {
+	Fields                []string `url:"fields,omitempty,comma"`
+	Limit                 int      `url:"limit,omitempty"`
+	PresentmentCurrencies []string `url:"presentment_currencies,omitempty,comma"`
+	SinceId               int      `url:"since_id,omitempty"`
+}
+
+func (v VariantRequestOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(v)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", v)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
+}
+
+/*
+Used by VariantUpdatePrice so that an empty compare at price is sent as null, which clears it.
+*/
+type variantPriceUpdate struct {
+	Id             int     `json:"id"`
+	Price          string  `json:"price,omitempty"`
+	CompareAtPrice *string `json:"compare_at_price"`
+}
+
+type variantPriceUpdateWrapper struct {
+	Variant variantPriceUpdate `json:"variant"`
+}
+
+func (v variantPriceUpdateWrapper) GetResourceName() string {
+	return "variants"
+}
+
+func (v variantPriceUpdateWrapper) BuildUpdateUrl(request Request) string {
+	return BuildIdUrl(request, v.GetResourceName(), v.Variant.Id)
+}
+
+func (r *RestAdminClient) VariantList(context Ctx, productId int, options VariantRequestOptions) (results []ProductVariant, next string, err error) {
+	var wrapper = &VariantsWrapper{ProductId: productId}
+	next, err = r.List(context, options, wrapper)
+	results = wrapper.Variants
+	return
+}
+
+type VariantIterator struct {
+	*Iterator
+}
+
+/*
+The variants on the current page.
+*/
+func (i *VariantIterator) Variants() []ProductVariant {
+	if i.Page() == nil {
+		return nil
+	}
+
+	return i.Page().(*VariantsWrapper).Variants
+}
+
+func (r *RestAdminClient) VariantListIterator(context Ctx, productId int, options VariantRequestOptions) *VariantIterator {
+	return &VariantIterator{r.NewIterator(context, options, func() Lister { return &VariantsWrapper{ProductId: productId} })}
+}
+
+func (r *RestAdminClient) VariantGet(context Ctx, id int) (result *ProductVariant, err error) {
+	wrapper := &VariantWrapper{Variant: &ProductVariant{Id: id}}
+	err = r.Get(context, wrapper)
+	result = wrapper.Variant
+
+	return
+}
+
+func (r *RestAdminClient) VariantCount(context Ctx, productId int) (count int, err error) {
+	return r.Count(context, productPath(productId)+"/variants", nil)
+}
+
+func (r *RestAdminClient) VariantCreate(context Ctx, productId int, request ProductVariant) (result *ProductVariant, err error) {
+	request.ProductId = productId
+	var returnWrapper = new(VariantWrapper)
+	err = r.Create(context, returnWrapper, VariantWrapper{Variant: &request})
+	result = returnWrapper.Variant
+
+	return
+}
+
+/*
+Updates the variant on its own, only the fields that are set are sent so the product and its other
+variants are left alone. Presentment prices are read only and are never sent.
+*/
+func (r *RestAdminClient) VariantUpdate(context Ctx, request ProductVariant) (result *ProductVariant, err error) {
+	request.PresentmentPrices = nil
+	var returnWrapper = new(VariantWrapper)
+	err = r.Update(context, returnWrapper, VariantWrapper{Variant: &request})
+	result = returnWrapper.Variant
+
+	return
+}
+
+/*
+Sends only the price and compare at price of the variant, an empty compareAtPrice removes it.
+The returned variant has the recalculated presentment prices.
+*/
+func (r *RestAdminClient) VariantUpdatePrice(context Ctx, id int, price string, compareAtPrice string) (result *ProductVariant, err error) {
+	update := variantPriceUpdate{Id: id, Price: price}
+	if compareAtPrice != "" {
+		update.CompareAtPrice = &compareAtPrice
+	}
+
+	var returnWrapper = new(VariantWrapper)
+	err = r.Update(context, returnWrapper, variantPriceUpdateWrapper{Variant: update})
+	result = returnWrapper.Variant
+
+	return
+}
+
+func (r *RestAdminClient) VariantDelete(context Ctx, productId int, id int) (err error) {
+	return r.Delete(context, productPath(productId)+"/variants", id)
+}
+
+func productPath(id int) string {
+	return "products/" + strconv.Itoa(id)
+}
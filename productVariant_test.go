@@ -0,0 +1,127 @@
+package shopify
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestVariantUpdatePrice(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != "PUT" || req.URL.Path != "/admin/api/2020-10/variants/808950810.json" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var raw map[string]map[string]interface{}
+		_ = json.NewDecoder(req.Body).Decode(&raw)
+		compareAtPrice, sent := raw["variant"]["compare_at_price"]
+		if len(raw["variant"]) != 3 || raw["variant"]["price"] != "99.00" || !sent || compareAtPrice != nil {
+			rw.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`{"variant":{"id":808950810,"product_id":632910392,"price":"99.00","compare_at_price":null,
+			"presentment_prices":[{"price":{"currency_code":"USD","amount":"99.00"},"compare_at_price":null}]}}`))
+	})
+	defer server.Close()
+
+	variant, err := client.VariantUpdatePrice(requestContext, 808950810, "99.00", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if variant.Price != "99.00" || len(variant.PresentmentPrices) != 1 || variant.PresentmentPrices[0].CompareAtPrice != nil {
+		t.Errorf("unexpected variant %+v", variant)
+	}
+}
+
+func TestVariantListAndDelete(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/admin/api/2020-10/products/632910392/variants.json" && req.URL.RawQuery == "limit=2":
+			_, _ = rw.Write([]byte(`{"variants":[{"id":39072856,"product_id":632910392},{"id":49148385,"product_id":632910392,
+				"presentment_prices":[{"price":{"currency_code":"USD","amount":"199.00"},"compare_at_price":{"currency_code":"USD","amount":"249.00"}}]}]}`))
+		case req.Method == "GET" && req.URL.Path == "/admin/api/2020-10/products/632910392/variants/count.json":
+			_, _ = rw.Write([]byte(`{"count":2}`))
+		case req.Method == "DELETE" && req.URL.Path == "/admin/api/2020-10/products/632910392/variants/39072856.json":
+			_, _ = rw.Write([]byte(`{}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	variants, _, err := client.VariantList(requestContext, 632910392, VariantRequestOptions{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(variants) != 2 || variants[1].PresentmentPrices[0].CompareAtPrice.Amount != "249.00" {
+		t.Errorf("unexpected variants %+v", variants)
+	}
+
+	count, err := client.VariantCount(requestContext, 632910392)
+	if err != nil || count != 2 {
+		t.Errorf("unexpected count %v %v", count, err)
+	}
+
+	if err = client.VariantDelete(requestContext, 632910392, 39072856); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVariantCreate(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != "POST" || req.URL.Path != "/admin/api/2020-10/products/632910392/variants.json" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var raw map[string]map[string]interface{}
+		_ = json.NewDecoder(req.Body).Decode(&raw)
+		if len(raw["variant"]) != 3 || raw["variant"]["option1"] != "Yellow" || raw["variant"]["price"] != "1.00" ||
+			raw["variant"]["product_id"] != float64(632910392) {
+			rw.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = rw.Write([]byte(`{"variant":{"id":1070325019,"product_id":632910392,"option1":"Yellow","price":"1.00"}}`))
+	})
+	defer server.Close()
+
+	variant, err := client.VariantCreate(requestContext, 632910392, ProductVariant{OptionOne: "Yellow", Price: "1.00"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if variant.Id != 1070325019 || variant.ProductId != 632910392 || variant.OptionOne != "Yellow" {
+		t.Errorf("unexpected variant %+v", variant)
+	}
+}
+
+func TestVariantUpdateSendsFalse(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != "PUT" || req.URL.Path != "/admin/api/2020-10/variants/808950810.json" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var raw map[string]map[string]interface{}
+		_ = json.NewDecoder(req.Body).Decode(&raw)
+		taxable, sent := raw["variant"]["taxable"]
+		if !sent || taxable != false || raw["variant"]["requires_shipping"] != false {
+			rw.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`{"variant":{"id":808950810,"product_id":632910392,"taxable":false,"requires_shipping":false}}`))
+	})
+	defer server.Close()
+
+	variant, err := client.VariantUpdate(requestContext, ProductVariant{Id: 808950810, Taxable: Bool(false), RequiresShipping: Bool(false)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if variant.Taxable == nil || *variant.Taxable || variant.RequiresShipping == nil || *variant.RequiresShipping {
+		t.Errorf("unexpected variant %+v", variant)
+	}
+}
@@ -0,0 +1,84 @@
+package shopify
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestProductListAutoPaginate(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/admin/api/2020-10/products.json" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if req.URL.RawQuery == "limit=1&vendor=Apple" {
+			rw.Header().Set("Link", "<https://"+req.Host+"/admin/api/2020-10/products.json?limit=1&page_info=abc>; rel=\"next\"")
+			_, _ = rw.Write([]byte(`{"products":[{"id":632910392,"title":"IPod Nano - 8GB"}]}`))
+			return
+		}
+
+		if req.URL.RawQuery == "limit=1&page_info=abc" {
+			rw.Header().Set("Link", "<https://"+req.Host+"/admin/api/2020-10/products.json?limit=1&page_info=xyz>; rel=\"previous\"")
+			_, _ = rw.Write([]byte(`{"products":[{"id":921728736,"title":"IPod Touch 8GB"}]}`))
+			return
+		}
+
+		rw.WriteHeader(http.StatusBadRequest)
+	})
+	defer server.Close()
+
+	requestContext.AutoPaginate = true
+	products, next, err := client.ProductList(requestContext, ProductRequestOptions{Limit: 1, Vendor: "Apple"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(products) != 2 || products[0].Id != 632910392 || products[1].Id != 921728736 || next != "" {
+		t.Errorf("unexpected products %+v next %v", products, next)
+	}
+}
+
+func TestProductCreateUpdateCount(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/products.json":
+			var wrapper ProductWrapper
+			_ = json.NewDecoder(req.Body).Decode(&wrapper)
+			wrapper.Product.Id = 1071559748
+			rw.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(rw).Encode(wrapper)
+		case req.Method == "PUT" && req.URL.Path == "/admin/api/2020-10/products/1071559748.json":
+			var raw map[string]map[string]interface{}
+			_ = json.NewDecoder(req.Body).Decode(&raw)
+			if _, ok := raw["product"]["variants"]; ok {
+				rw.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			_, _ = rw.Write([]byte(`{"product":{"id":1071559748,"title":"Burton Custom Freestyle 151","tags":"Barnes & Noble"}}`))
+		case req.Method == "GET" && req.URL.Path == "/admin/api/2020-10/products/count.json" && req.URL.RawQuery == "collection_id=841564295":
+			_, _ = rw.Write([]byte(`{"count":2}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	product, err := client.ProductCreate(requestContext, Product{
+		Title:    "Burton Custom Freestyle 151",
+		Variants: []ProductVariant{{OptionOne: "First", Price: "10.00"}},
+	})
+	if err != nil || product.Id != 1071559748 || product.Variants[0].Price != "10.00" {
+		t.Fatalf("unexpected created product %+v %v", product, err)
+	}
+
+	product, err = client.ProductUpdate(requestContext, Product{Id: product.Id, Tags: "Barnes & Noble"})
+	if err != nil || product.Tags != "Barnes & Noble" {
+		t.Errorf("unexpected updated product %+v %v", product, err)
+	}
+
+	count, err := client.ProductCount(requestContext, ProductRequestOptions{CollectionId: 841564295})
+	if err != nil || count != 2 {
+		t.Errorf("unexpected count %v %v", count, err)
+	}
+}
@@ -0,0 +1,187 @@
+package shopify
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	XShopifyShopApiCallLimit = "X-Shopify-Shop-Api-Call-Limit"
+	RetryAfter               = "Retry-After"
+
+	defaultBucketSize    = 40
+	defaultThreshold     = 0.8
+	defaultRetryAfter    = time.Second
+	bucketDrainInSeconds = 20
+)
+
+/*
+RateLimiter tracks the leaky bucket Shopify keeps for every shop and holds requests back
+before the bucket overflows. The bucket size and fill level are taken from the
+X-Shopify-Shop-Api-Call-Limit header of every response, and a 429 pauses all requests
+for that shop until the Retry-After period has passed.
+
+A single RateLimiter is safe to share between goroutines and between shops.
+*/
+type RateLimiter struct {
+	// The fraction of the bucket that may be used before requests start waiting, defaults to 0.8
+	Threshold float64
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	used       float64
+	size       float64
+	updated    time.Time
+	retryAfter time.Time
+}
+
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		Threshold: defaultThreshold,
+		buckets:   make(map[string]*bucket),
+	}
+}
+
+/*
+Blocks until a request for the shop can be sent without filling the bucket past the threshold.
+Every call that returns without an error reserves a slot in the bucket.
+*/
+func (l *RateLimiter) Wait(ctx context.Context, shopName string) error {
+	for {
+		l.mu.Lock()
+		b := l.bucket(shopName)
+		now := time.Now()
+		b.leak(now)
+		wait := b.delay(now, l.threshold())
+		if wait <= 0 {
+			b.used++
+			l.mu.Unlock()
+			return nil
+		}
+		l.mu.Unlock()
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+/*
+Records the bucket state reported by a response. A 429 response blocks the shop until
+the Retry-After period has passed.
+*/
+func (l *RateLimiter) Update(shopName string, resp *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(shopName)
+	now := time.Now()
+	b.leak(now)
+
+	if used, size, ok := parseCallLimit(resp.Header.Get(XShopifyShopApiCallLimit)); ok {
+		b.size = size
+		if used > b.used {
+			b.used = used
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		b.retryAfter = now.Add(ParseRetryAfter(resp.Header.Get(RetryAfter)))
+	}
+}
+
+func (l *RateLimiter) bucket(shopName string) *bucket {
+	if l.buckets == nil {
+		l.buckets = make(map[string]*bucket)
+	}
+
+	b, ok := l.buckets[shopName]
+	if !ok {
+		b = &bucket{size: defaultBucketSize, updated: time.Now()}
+		l.buckets[shopName] = b
+	}
+
+	return b
+}
+
+func (l *RateLimiter) threshold() float64 {
+	if l.Threshold <= 0 || l.Threshold > 1 {
+		return defaultThreshold
+	}
+
+	return l.Threshold
+}
+
+// Shopify drains a full bucket in 20 seconds, 2/s for a 40 bucket and 4/s for an 80 bucket.
+func (b *bucket) leakRate() float64 {
+	return b.size / bucketDrainInSeconds
+}
+
+func (b *bucket) leak(now time.Time) {
+	b.used -= now.Sub(b.updated).Seconds() * b.leakRate()
+	if b.used < 0 {
+		b.used = 0
+	}
+	b.updated = now
+}
+
+func (b *bucket) delay(now time.Time, threshold float64) time.Duration {
+	if now.Before(b.retryAfter) {
+		return b.retryAfter.Sub(now)
+	}
+
+	limit := b.size * threshold
+	if limit < 1 {
+		limit = 1
+	}
+
+	overflow := b.used + 1 - limit
+	if overflow <= 0 {
+		return 0
+	}
+
+	return time.Duration(overflow / b.leakRate() * float64(time.Second))
+}
+
+/*
+Parses the X-Shopify-Shop-Api-Call-Limit header, which has the form "32/40".
+*/
+func parseCallLimit(header string) (used float64, size float64, ok bool) {
+	parts := strings.Split(header, "/")
+	if len(parts) != 2 {
+		return
+	}
+
+	used, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return
+	}
+
+	size, err = strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil || size <= 0 {
+		return
+	}
+
+	ok = true
+	return
+}
+
+/*
+Parses the Retry-After header, which Shopify sends as a number of seconds ("2.0").
+Falls back to one second when the header is missing or malformed.
+*/
+func ParseRetryAfter(header string) time.Duration {
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(header), 64)
+	if err != nil || seconds <= 0 {
+		return defaultRetryAfter
+	}
+
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,109 @@
+package shopify
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseCallLimit(t *testing.T) {
+	used, size, ok := parseCallLimit("32/40")
+	if !ok || used != 32 || size != 40 {
+		t.Errorf("unexpected call limit parse %v %v %v", used, size, ok)
+	}
+
+	if _, _, ok := parseCallLimit("garbage"); ok {
+		t.Error("expected a malformed header to be rejected")
+	}
+}
+
+func TestRateLimiterThrottlesBeforeBucketFills(t *testing.T) {
+	limiter := NewRateLimiter()
+	limiter.Update("test.myshopify.com", &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{XShopifyShopApiCallLimit: []string{"39/40"}},
+	})
+
+	b := limiter.bucket("test.myshopify.com")
+	if wait := b.delay(time.Now(), limiter.threshold()); wait <= 0 {
+		t.Errorf("expected to wait with a nearly full bucket, got %v", wait)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx, "test.myshopify.com"); err == nil {
+		t.Error("expected the wait to be cut short by the context")
+	}
+
+	if err := limiter.Wait(context.Background(), "other.myshopify.com"); err != nil {
+		t.Errorf("a different shop should not be throttled %v", err)
+	}
+}
+
+func TestRateLimiterObeysRetryAfter(t *testing.T) {
+	limiter := NewRateLimiter()
+	limiter.Update("test.myshopify.com", &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{RetryAfter: []string{"0.05"}},
+	})
+
+	start := time.Now()
+	if err := limiter.Wait(context.Background(), "test.myshopify.com"); err != nil {
+		t.Error(err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected to wait for the retry after period, waited %v", elapsed)
+	}
+}
+
+func TestRequestRetriesRateLimitedResponses(t *testing.T) {
+	var calls int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			rw.Header().Set(RetryAfter, "0.01")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		rw.Header().Set(XShopifyShopApiCallLimit, "1/40")
+		_, _ = rw.Write([]byte(`{"webhooks":[]}`))
+	}))
+	defer server.Close()
+
+	client := RestAdminClient{
+		Http:    server.Client(),
+		Logger:  log.New(os.Stdout, "", log.Ldate|log.Ltime|log.Lshortfile),
+		Version: VERSION_2019_07,
+		Limiter: NewRateLimiter(),
+	}
+
+	serverUrl, _ := url.Parse(server.URL)
+	requestContext := Ctx{
+		AccessToken: "thisisatoken",
+		ShopName:    serverUrl.Host,
+		Ctx:         context.Background(),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.WebhookList(requestContext, WebHookRequestOptions{}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls != 6 {
+		t.Errorf("expected one retried request, got %v calls", calls)
+	}
+}
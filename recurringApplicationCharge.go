@@ -1,172 +1,288 @@
 package shopify
 
 import (
-	"bytes"
 	"encoding/json"
-	"errors"
 	"github.com/google/go-querystring/query"
-	"io/ioutil"
-	"net/http"
+	"github.com/pkg/errors"
 	"strconv"
 )
 
+/*
+A recurring charge for the app's plan. CappedAmount and Terms are only used by plans with usage charges,
+the usage charges of a billing period can't add up to more than the capped amount.
+*/
 type RecurringApplicationCharge struct {
+	ActivatedOn     string `json:"activated_on,omitempty"`
+	BalanceUsed     string `json:"balance_used,omitempty"`
+	BillingOn       string `json:"billing_on,omitempty"`
+	CancelledOn     string `json:"cancelled_on,omitempty"`
+	CappedAmount    string `json:"capped_amount,omitempty"`
 	ConfirmationUrl string `json:"confirmation_url,omitempty"`
+	CreatedAt       string `json:"created_at,omitempty"`
 	Id              int    `json:"id,omitempty"`
 	Name            string `json:"name,omitempty"`
 	Price           string `json:"price,omitempty"`
 	ReturnUrl       string `json:"return_url,omitempty"`
 	Status          string `json:"status,omitempty"`
+	Terms           string `json:"terms,omitempty"`
 	Test            bool   `json:"test,omitempty"`
 	TrialDays       int    `json:"trial_days,omitempty"`
+	TrialEndsOn     string `json:"trial_ends_on,omitempty"`
+	UpdatedAt       string `json:"updated_at,omitempty"`
+
+	UpdateCappedAmountUrl string `json:"update_capped_amount_url,omitempty"`
+}
+
+type RecurringApplicationChargeOptions struct {
+	SinceId int    `url:"since_id,omitempty"`
+	Fields  string `url:"fields,omitempty"`
+	// Deprecated: set AutoPaginate on the Ctx instead, it is kept as an alias for it.
+	All bool `url:"-"`
 }
 
-type RecurringApplicationChargeOptons struct {
-	SinceId int    `json:"since_id, omitempty"`
-	Fields  string `json:"fields, omitempty"`
-	All     bool
+// Deprecated: use RecurringApplicationChargeOptions, the misspelled name is kept for existing callers.
+type RecurringApplicationChargeOptons = RecurringApplicationChargeOptions
+
+func (r RecurringApplicationChargeOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(r)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", r)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
 }
 
 type RecurringApplicationChargeWrapper struct {
-	RecurringApplicationCharges RecurringApplicationCharge `json:"recurring_application_charge,omitempty"`
+	RecurringApplicationCharge *RecurringApplicationCharge `json:"recurring_application_charge"`
 }
 
-type RecurringApplicationChargesWrapper struct {
-	RecurringApplicationCharges []RecurringApplicationCharge `json:"recurring_application_charges,omitempty"`
+func (r RecurringApplicationChargeWrapper) GetResourceName() string {
+	return "recurring_application_charges"
 }
 
-func (c *RestAdminClient) RecurringApplicationChargeCreate(details Ctx, request RecurringApplicationCharge) (result RecurringApplicationCharge, err error) {
-	requestUrl := "https://" + details.ShopName + "/admin/recurring_application_charges.json"
+func (r RecurringApplicationChargeWrapper) GetId() int {
+	return r.RecurringApplicationCharge.Id
+}
 
-	c.Logger.Printf("Making the recurring application charge request for shop %s using URL %s\n", details.ShopName, requestUrl)
+func (r RecurringApplicationChargeWrapper) BuildGetUrl(request Request) string {
+	return BuildIdUrl(request, r.GetResourceName(), r.GetId())
+}
 
-	requestStr, err := json.Marshal(RecurringApplicationChargeWrapper{request})
-	if err != nil {
-		return
-	}
+func (r RecurringApplicationChargeWrapper) BuildCreateUrl(request Request) string {
+	return BuildSimpleUrl(request, r.GetResourceName())
+}
 
-	req, err := http.NewRequest("POST", requestUrl, bytes.NewBuffer(requestStr))
-	if err != nil {
+type RecurringApplicationCharges struct {
+	RecurringApplicationCharges []RecurringApplicationCharge `json:"recurring_application_charges"`
+}
+
+type RecurringApplicationChargesWrapper struct {
+	RecurringApplicationCharges []RecurringApplicationCharge
+}
+
+func (r *RecurringApplicationChargesWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper RecurringApplicationCharges
+	if err = json.Unmarshal(data, &wrapper); err != nil {
 		return
 	}
 
-	req.WithContext(details.Ctx)
+	r.RecurringApplicationCharges = append(r.RecurringApplicationCharges, wrapper.RecurringApplicationCharges...)
+	return
+}
 
-	req.Header.Add("X-Shopify-Access-Token", details.AccessToken)
-	req.Header.Set("Content-Type", "application/json")
+func (r RecurringApplicationChargesWrapper) GetResourceName() string {
+	return "recurring_application_charges"
+}
 
-	resp, err := c.Http.Do(req)
-	defer resp.Body.Close()
-	if err != nil {
+/*
+Creates the charge, redirect the merchant to the ConfirmationUrl of the result to approve it.
+*/
+func (r *RestAdminClient) RecurringApplicationChargeCreate(context Ctx, request RecurringApplicationCharge) (result *RecurringApplicationCharge, err error) {
+	if request.ReturnUrl == "" {
+		err = errors.New("a recurring application charge needs a return url to send the merchant back to")
 		return
 	}
 
-	if resp.StatusCode != 201 {
-		c.Logger.Println("The billing request response status code is: ", resp.StatusCode)
-		return result, errors.New("The response from the server was not the expected status code")
-	}
+	var returnWrapper = new(RecurringApplicationChargeWrapper)
+	err = r.Create(context, returnWrapper, RecurringApplicationChargeWrapper{RecurringApplicationCharge: &request})
+	result = returnWrapper.RecurringApplicationCharge
 
-	buf, _ := ioutil.ReadAll(resp.Body)
-	c.Logger.Println("The response for the recurring billing request is: ", string(buf))
-	wrapper := RecurringApplicationChargeWrapper{}
-	err = json.Unmarshal(buf, &wrapper)
-	if err != nil {
-		c.Logger.Println("Error unmarshaling the billing response", err.Error())
-	}
+	return
+}
 
-	c.Logger.Println("The result of the unmarshaling: ", wrapper)
-	result = wrapper.RecurringApplicationCharges
+func (r *RestAdminClient) RecurringApplicationChargeGet(context Ctx, id int) (result *RecurringApplicationCharge, err error) {
+	wrapper := &RecurringApplicationChargeWrapper{RecurringApplicationCharge: &RecurringApplicationCharge{Id: id}}
+	err = r.Get(context, wrapper)
+	result = wrapper.RecurringApplicationCharge
 
 	return
 }
 
-func (c *RestAdminClient) RecurringApplicationChargeActivate(details Ctx, request RecurringApplicationCharge) (result RecurringApplicationCharge, err error) {
-	if details.AccessToken == "" || details.ShopName == "" {
-		err = errors.New("Missing the shop name or the access token from the details object inside the activate billing call.")
-		return
+/*
+Activates a charge the merchant accepted. Charges are activated by Shopify on acceptance from api
+version 2021-01, this is only needed for the older versions.
+*/
+func (r *RestAdminClient) RecurringApplicationChargeActivate(context Ctx, request RecurringApplicationCharge) (result *RecurringApplicationCharge, err error) {
+	var wrapper = RecurringApplicationChargeWrapper{RecurringApplicationCharge: &request}
+	var returnWrapper RecurringApplicationChargeWrapper
+	err = r.Send(context, "POST", recurringApplicationChargePath(request.Id)+"/activate", nil, wrapper, &returnWrapper)
+	result = returnWrapper.RecurringApplicationCharge
+
+	return
+}
+
+func (r *RestAdminClient) RecurringApplicationChargeList(context Ctx, options RecurringApplicationChargeOptions) (results []RecurringApplicationCharge, err error) {
+	if options.All {
+		context.AutoPaginate = true
 	}
 
-	requestUrl := "https://" + details.ShopName + "/admin/recurring_application_charges/" + strconv.Itoa(request.Id) + "/activate.json"
+	var wrapper = &RecurringApplicationChargesWrapper{}
+	_, err = r.List(context, options, wrapper)
+	results = wrapper.RecurringApplicationCharges
+	return
+}
 
-	c.Logger.Printf("Requesting to activate recurring application charge with id %s for shop %s using URL %s\n", strconv.Itoa(request.Id), details.ShopName, requestUrl)
+type RecurringApplicationChargeIterator struct {
+	*Iterator
+}
 
-	requestStr, err := json.Marshal(RecurringApplicationChargeWrapper{request})
-	if err != nil {
-		return
+/*
+The recurring application charges on the current page.
+*/
+func (i *RecurringApplicationChargeIterator) RecurringApplicationCharges() []RecurringApplicationCharge {
+	if i.Page() == nil {
+		return nil
 	}
 
-	req, err := http.NewRequest("POST", requestUrl, bytes.NewBuffer(requestStr))
-	if err != nil {
-		return
-	}
+	return i.Page().(*RecurringApplicationChargesWrapper).RecurringApplicationCharges
+}
 
-	req.WithContext(details.Ctx)
-	req.Header.Add("X-Shopify-Access-Token", details.AccessToken)
-	req.Header.Set("Content-Type", "application/json")
+func (r *RestAdminClient) RecurringApplicationChargeListIterator(context Ctx, options RecurringApplicationChargeOptions) *RecurringApplicationChargeIterator {
+	return &RecurringApplicationChargeIterator{r.NewIterator(context, options, func() Lister { return &RecurringApplicationChargesWrapper{} })}
+}
 
-	resp, err := c.Http.Do(req)
-	defer resp.Body.Close()
-	if err != nil {
-		return
-	}
+/*
+Cancels the charge, the merchant stops being billed for it straight away.
+*/
+func (r *RestAdminClient) RecurringApplicationChargeCancel(context Ctx, id int) (err error) {
+	return r.Delete(context, "recurring_application_charges", id)
+}
+
+type recurringApplicationChargeCustomizeOptions struct {
+	CappedAmount string `url:"recurring_application_charge[capped_amount]"`
+}
 
-	buf, _ := ioutil.ReadAll(resp.Body)
-	c.Logger.Println("This is the response recieved from activating the billing: ", string(buf))
-	wrapper := RecurringApplicationChargeWrapper{}
-	err = json.Unmarshal(buf, &wrapper)
+func (r recurringApplicationChargeCustomizeOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(r)
 	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", r)
 		return
 	}
 
-	result = wrapper.RecurringApplicationCharges
+	queryParams = values.Encode()
+	return
+}
 
-	if resp.StatusCode != 200 {
-		err = errors.New("Expected status code to be 201")
-		return
-	}
+/*
+Raises the capped amount of an active charge. The merchant has to approve the new amount at the
+update_capped_amount_url of the returned charge before it applies.
+*/
+func (r *RestAdminClient) RecurringApplicationChargeCustomize(context Ctx, id int, cappedAmount string) (result *RecurringApplicationCharge, err error) {
+	var returnWrapper RecurringApplicationChargeWrapper
+	options := recurringApplicationChargeCustomizeOptions{CappedAmount: cappedAmount}
+	err = r.Send(context, "PUT", recurringApplicationChargePath(id)+"/customize", options, nil, &returnWrapper)
+	result = returnWrapper.RecurringApplicationCharge
 
 	return
 }
 
-func (c *RestAdminClient) RecurringApplicationChargeList(details Ctx, options RecurringApplicationChargeOptons) (charges []RecurringApplicationCharge, err error) {
-	v, err := query.Values(options)
-	if err != nil {
-		c.Logger.Println("there's an issue setting up the query params while request the recurring application charges")
-		return
-	}
-	requestUrl := "https://" + details.ShopName + "/admin/" + c.Version.String() + "recurring_application_charges.json?" + v.Encode()
-	c.Logger.Println(requestUrl)
+/*
+A charge against the capped amount of a recurring application charge.
+*/
+type UsageCharge struct {
+	BalanceRemaining             string `json:"balance_remaining,omitempty"`
+	BalanceUsed                  string `json:"balance_used,omitempty"`
+	BillingOn                    string `json:"billing_on,omitempty"`
+	CreatedAt                    string `json:"created_at,omitempty"`
+	Description                  string `json:"description,omitempty"`
+	Id                           int    `json:"id,omitempty"`
+	Price                        string `json:"price,omitempty"`
+	RecurringApplicationChargeId int    `json:"recurring_application_charge_id,omitempty"`
+	RiskLevel                    int    `json:"risk_level,omitempty"`
+}
 
-	req, err := http.NewRequest("GET", requestUrl, nil)
-	if err != nil {
-		return
-	}
+type UsageChargeWrapper struct {
+	UsageCharge *UsageCharge `json:"usage_charge"`
+}
 
-	req.Header.Add("X-Shopify-Access-Token", details.AccessToken)
+func (u UsageChargeWrapper) GetResourceName() string {
+	return recurringApplicationChargePath(u.UsageCharge.RecurringApplicationChargeId) + "/usage_charges"
+}
 
-	resp, err := c.Http.Do(req)
-	if err != nil {
-		return
-	}
+func (u UsageChargeWrapper) GetId() int {
+	return u.UsageCharge.Id
+}
 
-	buf, _ := ioutil.ReadAll(resp.Body)
-	c.Logger.Println("This is the response for the recurring application charge: ", string(buf))
-	wrapper := RecurringApplicationChargesWrapper{}
-	err = json.Unmarshal(buf, &wrapper)
-	if err != nil {
-		return
-	}
+func (u UsageChargeWrapper) BuildGetUrl(request Request) string {
+	return BuildIdUrl(request, u.GetResourceName(), u.GetId())
+}
 
-	charges = wrapper.RecurringApplicationCharges
+func (u UsageChargeWrapper) BuildCreateUrl(request Request) string {
+	return BuildSimpleUrl(request, u.GetResourceName())
+}
 
-	if len(charges) == 0 || !options.All {
+type UsageCharges struct {
+	UsageCharges []UsageCharge `json:"usage_charges"`
+}
+
+type UsageChargesWrapper struct {
+	RecurringApplicationChargeId int
+	UsageCharges                 []UsageCharge
+}
+
+func (u *UsageChargesWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper UsageCharges
+	if err = json.Unmarshal(data, &wrapper); err != nil {
 		return
 	}
 
-	options.SinceId = charges[len(charges)-1].Id
-	nextResult, err := c.RecurringApplicationChargeList(details, options)
+	u.UsageCharges = append(u.UsageCharges, wrapper.UsageCharges...)
+	return
+}
+
+func (u UsageChargesWrapper) GetResourceName() string {
+	return recurringApplicationChargePath(u.RecurringApplicationChargeId) + "/usage_charges"
+}
+
+/*
+Bills the merchant price under the recurring application charge, the description is shown on their invoice.
+*/
+func (r *RestAdminClient) UsageChargeCreate(context Ctx, recurringApplicationChargeId int, request UsageCharge) (result *UsageCharge, err error) {
+	request.RecurringApplicationChargeId = recurringApplicationChargeId
+	var returnWrapper = new(UsageChargeWrapper)
+	err = r.Create(context, returnWrapper, UsageChargeWrapper{UsageCharge: &request})
+	result = returnWrapper.UsageCharge
 
-	charges = append(charges, nextResult...)
+	return
+}
 
+func (r *RestAdminClient) UsageChargeList(context Ctx, recurringApplicationChargeId int) (results []UsageCharge, err error) {
+	var wrapper = &UsageChargesWrapper{RecurringApplicationChargeId: recurringApplicationChargeId}
+	_, err = r.List(context, emptyOptions{}, wrapper)
+	results = wrapper.UsageCharges
 	return
 }
+
+func (r *RestAdminClient) UsageChargeGet(context Ctx, recurringApplicationChargeId int, id int) (result *UsageCharge, err error) {
+	wrapper := &UsageChargeWrapper{UsageCharge: &UsageCharge{RecurringApplicationChargeId: recurringApplicationChargeId, Id: id}}
+	err = r.Get(context, wrapper)
+	result = wrapper.UsageCharge
+
+	return
+}
+
+func recurringApplicationChargePath(id int) string {
+	return "recurring_application_charges/" + strconv.Itoa(id)
+}
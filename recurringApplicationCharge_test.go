@@ -0,0 +1,57 @@
+package shopify
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestRecurringApplicationChargeCustomizeAndUsage(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "PUT" && req.URL.Path == "/admin/api/2020-10/recurring_application_charges/455696195/customize.json" &&
+			req.URL.Query().Get("recurring_application_charge[capped_amount]") == "200":
+			_, _ = rw.Write([]byte(`{"recurring_application_charge":{"id":455696195,"status":"active","capped_amount":"100.00",
+				"update_capped_amount_url":"https://test.myshopify.com/admin/charges/455696195/confirm_update_capped_amount"}}`))
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/recurring_application_charges/455696195/usage_charges.json":
+			var wrapper UsageChargeWrapper
+			_ = json.NewDecoder(req.Body).Decode(&wrapper)
+			if wrapper.UsageCharge.Price != "1.00" || wrapper.UsageCharge.Description != "Super Mega Plan 1000 emails" {
+				rw.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			rw.WriteHeader(http.StatusCreated)
+			_, _ = rw.Write([]byte(`{"usage_charge":{"id":1034618208,"description":"Super Mega Plan 1000 emails","price":"1.00","balance_used":"11.00","balance_remaining":"89.00"}}`))
+		case req.Method == "GET" && req.URL.Path == "/admin/api/2020-10/recurring_application_charges/455696195/usage_charges.json":
+			_, _ = rw.Write([]byte(`{"usage_charges":[{"id":1034618207,"price":"10.00"},{"id":1034618208,"price":"1.00"}]}`))
+		case req.Method == "DELETE" && req.URL.Path == "/admin/api/2020-10/recurring_application_charges/455696195.json":
+			_, _ = rw.Write([]byte(`{}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	if _, err := client.RecurringApplicationChargeCreate(requestContext, RecurringApplicationCharge{Name: "Super Duper Plan", Price: "10.00"}); err == nil {
+		t.Error("expected an error for a charge without a return url")
+	}
+
+	charge, err := client.RecurringApplicationChargeCustomize(requestContext, 455696195, "200")
+	if err != nil || charge.UpdateCappedAmountUrl == "" {
+		t.Fatalf("unexpected charge %+v %v", charge, err)
+	}
+
+	usage, err := client.UsageChargeCreate(requestContext, 455696195, UsageCharge{Description: "Super Mega Plan 1000 emails", Price: "1.00"})
+	if err != nil || usage.Id != 1034618208 || usage.BalanceRemaining != "89.00" {
+		t.Fatalf("unexpected usage charge %+v %v", usage, err)
+	}
+
+	usages, err := client.UsageChargeList(requestContext, 455696195)
+	if err != nil || len(usages) != 2 {
+		t.Errorf("unexpected usage charges %+v %v", usages, err)
+	}
+
+	if err = client.RecurringApplicationChargeCancel(requestContext, 455696195); err != nil {
+		t.Error(err)
+	}
+}
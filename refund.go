@@ -0,0 +1,192 @@
+package shopify
+
+import (
+	"encoding/json"
+	"github.com/google/go-querystring/query"
+	"github.com/pkg/errors"
+)
+
+const (
+	RestockTypeNoRestock = "no_restock"
+	RestockTypeCancel    = "cancel"
+	RestockTypeReturn    = "return"
+)
+
+type Refund struct {
+	CreatedAt        string            `json:"created_at,omitempty"`
+	Currency         string            `json:"currency,omitempty"`
+	Id               int               `json:"id,omitempty"`
+	Note             string            `json:"note,omitempty"`
+	Notify           bool              `json:"notify,omitempty"`
+	OrderAdjustments []OrderAdjustment `json:"order_adjustments,omitempty"`
+	OrderId          int               `json:"order_id,omitempty"`
+	ProcessedAt      string            `json:"processed_at,omitempty"`
+	RefundLineItems  []RefundLineItem  `json:"refund_line_items,omitempty"`
+	Shipping         *RefundShipping   `json:"shipping,omitempty"`
+	Transactions     []Transaction     `json:"transactions,omitempty"`
+	UserId           int               `json:"user_id,omitempty"`
+}
+
+/*
+A line item to refund. RestockType is one of the RestockType values, LocationId is the location
+restocked items go back to.
+*/
+type RefundLineItem struct {
+	Id          int       `json:"id,omitempty"`
+	LineItem    *LineItem `json:"line_item,omitempty"`
+	LineItemId  int       `json:"line_item_id,omitempty"`
+	LocationId  int       `json:"location_id,omitempty"`
+	Quantity    int       `json:"quantity,omitempty"`
+	RestockType string    `json:"restock_type,omitempty"`
+	Subtotal    Money     `json:"subtotal,omitempty"`
+	TotalTax    Money     `json:"total_tax,omitempty"`
+}
+
+/*
+The shipping to refund, either all of it with FullRefund or Amount. MaximumRefundable is only set by RefundCalculate.
+*/
+type RefundShipping struct {
+	Amount            Money `json:"amount,omitempty"`
+	FullRefund        bool  `json:"full_refund,omitempty"`
+	MaximumRefundable Money `json:"maximum_refundable,omitempty"`
+	Tax               Money `json:"tax,omitempty"`
+}
+
+type OrderAdjustment struct {
+	Amount    Money  `json:"amount,omitempty"`
+	Id        int    `json:"id,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+	OrderId   int    `json:"order_id,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	RefundId  int    `json:"refund_id,omitempty"`
+	TaxAmount Money  `json:"tax_amount,omitempty"`
+}
+
+type RefundWrapper struct {
+	Refund *Refund `json:"refund"`
+}
+
+func (r RefundWrapper) GetResourceName() string {
+	return orderPath(r.Refund.OrderId) + "/refunds"
+}
+
+func (r RefundWrapper) GetId() int {
+	return r.Refund.Id
+}
+
+func (r RefundWrapper) BuildGetUrl(request Request) string {
+	return BuildIdUrl(request, r.GetResourceName(), r.GetId())
+}
+
+func (r RefundWrapper) BuildCreateUrl(request Request) string {
+	return BuildSimpleUrl(request, r.GetResourceName())
+}
+
+type Refunds struct {
+	Refunds []Refund `json:"refunds"`
+}
+
+type RefundsWrapper struct {
+	OrderId int
+	Refunds []Refund
+}
+
+func (r *RefundsWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper Refunds
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return
+	}
+
+	r.Refunds = append(r.Refunds, wrapper.Refunds...)
+	return
+}
+
+func (r RefundsWrapper) GetResourceName() string {
+	return orderPath(r.OrderId) + "/refunds"
+}
+
+type RefundRequestOptions struct {
+	Fields         []string `url:"fields,omitempty,comma"`
+	InShopCurrency bool     `url:"in_shop_currency,omitempty"`
+	Limit          int      `url:"limit,omitempty"`
+}
+
+func (r RefundRequestOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(r)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", r)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
+}
+
+func (r *RestAdminClient) RefundList(context Ctx, orderId int, options RefundRequestOptions) (results []Refund, next string, err error) {
+	var wrapper = &RefundsWrapper{OrderId: orderId}
+	next, err = r.List(context, options, wrapper)
+	results = wrapper.Refunds
+	return
+}
+
+type RefundIterator struct {
+	*Iterator
+}
+
+/*
+The refunds on the current page.
+*/
+func (i *RefundIterator) Refunds() []Refund {
+	if i.Page() == nil {
+		return nil
+	}
+
+	return i.Page().(*RefundsWrapper).Refunds
+}
+
+func (r *RestAdminClient) RefundListIterator(context Ctx, orderId int, options RefundRequestOptions) *RefundIterator {
+	return &RefundIterator{r.NewIterator(context, options, func() Lister { return &RefundsWrapper{OrderId: orderId} })}
+}
+
+func (r *RestAdminClient) RefundGet(context Ctx, orderId int, id int) (result *Refund, err error) {
+	wrapper := &RefundWrapper{Refund: &Refund{OrderId: orderId, Id: id}}
+	err = r.Get(context, wrapper)
+	result = wrapper.Refund
+
+	return
+}
+
+/*
+Previews a refund without making it. Shopify works out the amounts of the refund line items and
+shipping, and suggests the transactions against the order's payments in Transactions.
+*/
+func (r *RestAdminClient) RefundCalculate(context Ctx, orderId int, request Refund) (result *Refund, err error) {
+	request.OrderId = orderId
+	var wrapper RefundWrapper
+	err = r.Send(context, "POST", orderPath(orderId)+"/refunds/calculate", nil, RefundWrapper{Refund: &request}, &wrapper)
+	result = wrapper.Refund
+
+	return
+}
+
+/*
+Creates the refund. The result of RefundCalculate can be passed straight in, its suggested_refund
+transactions are sent as refunds.
+*/
+func (r *RestAdminClient) RefundCreate(context Ctx, orderId int, request Refund) (result *Refund, err error) {
+	request.OrderId = orderId
+	transactions := make([]Transaction, len(request.Transactions))
+	for i, transaction := range request.Transactions {
+		if transaction.Kind == TransactionKindSuggestedRefund {
+			transaction.Kind = TransactionKindRefund
+		}
+		transactions[i] = transaction
+	}
+	request.Transactions = transactions
+
+	var returnWrapper = new(RefundWrapper)
+	err = r.Create(context, returnWrapper, RefundWrapper{Refund: &request})
+	result = returnWrapper.Refund
+
+	return
+}
@@ -0,0 +1,80 @@
+package shopify
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestMoneyJson(t *testing.T) {
+	var amounts struct {
+		Text   Money `json:"text"`
+		Number Money `json:"number"`
+		Null   Money `json:"null"`
+	}
+	err := json.Unmarshal([]byte(`{"text":"41.94","number":0.10000000000000001,"null":null}`), &amounts)
+	if err != nil || amounts.Text != "41.94" || amounts.Number != "0.10000000000000001" || amounts.Null != "" {
+		t.Errorf("unexpected amounts %+v %v", amounts, err)
+	}
+
+	buf, _ := json.Marshal(Transaction{Amount: "10.00"})
+	if string(buf) != `{"amount":"10.00"}` {
+		t.Errorf("unexpected transaction json %s", buf)
+	}
+
+	if err = json.Unmarshal([]byte(`{"text":true}`), &amounts); err == nil {
+		t.Error("expected an error for a boolean amount")
+	}
+	for _, invalid := range []string{`{"text":"ten"}`, `{"text":"10."}`, `{"text":"1,000.00"}`, `{"number":1e3}`} {
+		if err = json.Unmarshal([]byte(invalid), &amounts); err == nil {
+			t.Errorf("expected an error for %s", invalid)
+		}
+	}
+
+	if _, err = json.Marshal(Transaction{Amount: "$10.00"}); err == nil {
+		t.Error("expected an error when sending an invalid amount")
+	}
+}
+
+func TestRefundCalculateAndCreate(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/orders/450789469/refunds/calculate.json":
+			_, _ = rw.Write([]byte(`{"refund":{"shipping":{"amount":"0.00","maximum_refundable":"0.00"},
+				"refund_line_items":[{"quantity":1,"line_item_id":518995019,"location_id":24826418,"restock_type":"return","subtotal":195.66,"total_tax":3.98}],
+				"transactions":[{"order_id":450789469,"kind":"suggested_refund","gateway":"bogus","parent_id":801038806,"amount":"41.94","currency":"USD","maximum_refundable":"41.94"}]}}`))
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/orders/450789469/refunds.json":
+			var wrapper RefundWrapper
+			_ = json.NewDecoder(req.Body).Decode(&wrapper)
+			if wrapper.Refund.Transactions[0].Kind != TransactionKindRefund || wrapper.Refund.Transactions[0].Amount != "41.94" ||
+				wrapper.Refund.RefundLineItems[0].RestockType != RestockTypeReturn {
+				rw.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			rw.WriteHeader(http.StatusCreated)
+			_, _ = rw.Write([]byte(`{"refund":{"id":509562969,"order_id":450789469,"transactions":[{"id":179259969,"kind":"refund","status":"success","amount":"41.94"}]}}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	calculated, err := client.RefundCalculate(requestContext, 450789469, Refund{
+		Shipping:        &RefundShipping{FullRefund: true},
+		RefundLineItems: []RefundLineItem{{LineItemId: 518995019, Quantity: 1, RestockType: RestockTypeReturn}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calculated.RefundLineItems[0].Subtotal != "195.66" || calculated.Transactions[0].Kind != TransactionKindSuggestedRefund {
+		t.Fatalf("unexpected calculated refund %+v", calculated)
+	}
+
+	refund, err := client.RefundCreate(requestContext, 450789469, *calculated)
+	if err != nil || refund.Id != 509562969 || refund.Transactions[0].Status != "success" {
+		t.Errorf("unexpected refund %+v %v", refund, err)
+	}
+	if calculated.Transactions[0].Kind != TransactionKindSuggestedRefund {
+		t.Error("RefundCreate shouldn't change the calculated refund")
+	}
+}
@@ -0,0 +1,164 @@
+package shopify
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultRetryBaseDelay   = 500 * time.Millisecond
+	defaultRetryMaxDelay    = 30 * time.Second
+	defaultRetryMaxAttempts = 5
+)
+
+/*
+RetryPolicy decides whether a failed attempt should be tried again and how long to wait first.
+The attempt count starts at 1, and exactly one of resp or err is set.
+*/
+type RetryPolicy interface {
+	Retry(attempt int, elapsed time.Duration, request Request, resp *http.Response, err error) (wait time.Duration, retry bool)
+}
+
+/*
+ExponentialBackoff retries rate limited responses, 5xx responses and connection failures using
+full jitter exponential backoff. A 429 always waits at least as long as its Retry-After header.
+
+POST requests create resources and are only retried when RetryPost is set, with the exception
+of 429 responses, which Shopify rejects before doing any work.
+
+The zero value is usable and uses the defaults noted on each field.
+*/
+type ExponentialBackoff struct {
+	// The delay before the first retry, defaults to 500ms
+	BaseDelay time.Duration
+	// The cap on any single delay, defaults to 30s
+	MaxDelay time.Duration
+	// The total number of attempts including the first one, defaults to 5
+	MaxAttempts int
+	// When set, no retry is scheduled that would finish after this much time has elapsed
+	MaxElapsed time.Duration
+	// Allows retrying POST requests after 5xx responses and connection failures
+	RetryPost bool
+}
+
+func (b ExponentialBackoff) Retry(attempt int, elapsed time.Duration, request Request, resp *http.Response, err error) (wait time.Duration, retry bool) {
+	maxAttempts := b.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultRetryMaxAttempts
+	}
+	if attempt >= maxAttempts {
+		return
+	}
+
+	rateLimited := resp != nil && resp.StatusCode == http.StatusTooManyRequests
+	if !rateLimited && !isTransient(resp, err) {
+		return
+	}
+
+	if !rateLimited && request.Method == http.MethodPost && !b.RetryPost {
+		return
+	}
+
+	wait = b.backoff(attempt)
+	if rateLimited {
+		if retryAfter := ParseRetryAfter(resp.Header.Get(RetryAfter)); retryAfter > wait {
+			wait = retryAfter
+		}
+	}
+
+	if b.MaxElapsed > 0 && elapsed+wait > b.MaxElapsed {
+		return 0, false
+	}
+
+	retry = true
+	return
+}
+
+func (b ExponentialBackoff) backoff(attempt int) time.Duration {
+	base := b.BaseDelay
+	if base <= 0 {
+		base = defaultRetryBaseDelay
+	}
+
+	maxDelay := b.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultRetryMaxDelay
+	}
+
+	ceiling := float64(base) * math.Pow(2, float64(attempt-1))
+	if ceiling > float64(maxDelay) {
+		ceiling = float64(maxDelay)
+	}
+
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
+
+/*
+The policy used when RestAdminClient.Retry is nil and a Limiter is set, it only retries 429 responses.
+*/
+type rateLimitRetry struct{}
+
+func (rateLimitRetry) Retry(attempt int, elapsed time.Duration, request Request, resp *http.Response, err error) (wait time.Duration, retry bool) {
+	if attempt > maxRateLimitRetries || resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+
+	return ParseRetryAfter(resp.Header.Get(RetryAfter)), true
+}
+
+/*
+The policy used when neither RestAdminClient.Retry nor a Limiter is set, every attempt is final.
+*/
+type noRetry struct{}
+
+func (noRetry) Retry(attempt int, elapsed time.Duration, request Request, resp *http.Response, err error) (wait time.Duration, retry bool) {
+	return
+}
+
+/*
+Reports whether a response or error is worth another attempt: 5xx responses, connection resets,
+unexpected EOFs and network timeouts. Context cancellation is never transient.
+*/
+func isTransient(resp *http.Response, err error) bool {
+	if err == nil {
+		return resp != nil && resp.StatusCode >= 500
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+/*
+Blocks for the given duration, returning early with the context's error if it is cancelled.
+*/
+func sleepContext(ctx context.Context, wait time.Duration) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,124 @@
+package shopify
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryServerErrors(t *testing.T) {
+	var calls int32
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			rw.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`{"webhooks":[]}`))
+	})
+	defer server.Close()
+
+	client.Retry = ExponentialBackoff{BaseDelay: time.Millisecond}
+	if _, _, err := client.WebhookList(requestContext, WebHookRequestOptions{}); err != nil {
+		t.Error(err)
+	}
+
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %v", calls)
+	}
+}
+
+func TestRetryConnectionReset(t *testing.T) {
+	var calls int32
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			conn, _, _ := rw.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+
+		_, _ = rw.Write([]byte(`{"webhooks":[]}`))
+	})
+	defer server.Close()
+
+	client.Retry = ExponentialBackoff{BaseDelay: time.Millisecond}
+	if _, _, err := client.WebhookList(requestContext, WebHookRequestOptions{}); err != nil {
+		t.Error(err)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected 2 attempts, got %v", calls)
+	}
+}
+
+func TestRetrySkipsPost(t *testing.T) {
+	var calls int32
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.WriteHeader(http.StatusInternalServerError)
+	})
+	defer server.Close()
+
+	client.Retry = ExponentialBackoff{BaseDelay: time.Millisecond}
+	if _, err := client.WebhookCreate(requestContext, Webhook{Topic: ProductCreate}); err == nil {
+		t.Error("expected the create to fail")
+	}
+	if calls != 1 {
+		t.Errorf("expected the POST to be sent once, got %v", calls)
+	}
+
+	calls = 0
+	client.Retry = ExponentialBackoff{BaseDelay: time.Millisecond, MaxAttempts: 3, RetryPost: true}
+	if _, err := client.WebhookCreate(requestContext, Webhook{Topic: ProductCreate}); err == nil {
+		t.Error("expected the create to fail")
+	}
+	if calls != 3 {
+		t.Errorf("expected the POST to be retried up to the attempt limit, got %v", calls)
+	}
+}
+
+func TestNoRetryWithoutPolicyOrLimiter(t *testing.T) {
+	var calls int32
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.Header().Set(RetryAfter, "0.01")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	})
+	defer server.Close()
+	if _, _, err := client.WebhookList(requestContext, WebHookRequestOptions{}); !IsRateLimited(err) {
+		t.Errorf("expected a rate limited error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected a single attempt without a limiter, got %v", calls)
+	}
+}
+
+func TestRetryStopsOnCancel(t *testing.T) {
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer server.Close()
+
+	client.Retry = ExponentialBackoff{BaseDelay: time.Hour, MaxDelay: time.Hour}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	requestContext.Ctx = ctx
+
+	start := time.Now()
+	if _, _, err := client.WebhookList(requestContext, WebHookRequestOptions{}); err == nil {
+		t.Error("expected the cancelled request to fail")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("the retry did not stop on cancellation, took %v", elapsed)
+	}
+}
+
+func TestRetryMaxElapsed(t *testing.T) {
+	policy := ExponentialBackoff{BaseDelay: time.Second, MaxElapsed: time.Second}
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{RetryAfter: []string{"2.0"}}}
+	if _, retry := policy.Retry(1, 0, Request{Method: http.MethodGet}, resp, nil); retry {
+		t.Error("expected the retry to be refused once it would exceed the elapsed budget")
+	}
+}
@@ -1,67 +1,219 @@
 package shopify
 
 import (
-	"bytes"
 	"encoding/json"
-	"errors"
-	"io/ioutil"
-	"net/http"
+	"github.com/google/go-querystring/query"
+	"github.com/pkg/errors"
 )
 
+const ScriptTagEventOnload = "onload"
+
 type ScriptTag struct {
 	CreatedAt    string `json:"created_at,omitempty"`
 	Event        string `json:"event,omitempty"`
 	Id           int    `json:"id,omitempty"`
 	Src          string `json:"src,omitempty"`
 	DisplayScope string `json:"display_scope,omitempty"`
-	UpdateAt     string `json:"update_at,omitempty"`
+	Cache        bool   `json:"cache,omitempty"`
+	UpdatedAt    string `json:"updated_at,omitempty"`
 }
 
-type ScriptTageWrapper struct {
-	ScriptTag ScriptTag `json:"script_tag"`
+type ScriptTagWrapper struct {
+	ScriptTag *ScriptTag `json:"script_tag"`
 }
 
-func (c *RestAdminClient) ScriptTagCreate(details Ctx, request ScriptTag) (result ScriptTag, err error) {
-	requestUrl := "https://" + details.ShopName + "/admin/" + c.Version.String() + "script_tags.json"
+func (s ScriptTagWrapper) GetResourceName() string {
+	return "script_tags"
+}
 
-	c.Logger.Printf("Making the script tag request for shop %s using URL %s\n", details.ShopName, requestUrl)
+func (s ScriptTagWrapper) GetId() int {
+	return s.ScriptTag.Id
+}
 
-	requestStr, err := json.Marshal(ScriptTageWrapper{request})
-	if err != nil {
+func (s ScriptTagWrapper) BuildGetUrl(request Request) string {
+	return BuildIdUrl(request, s.GetResourceName(), s.GetId())
+}
+
+func (s ScriptTagWrapper) BuildCreateUrl(request Request) string {
+	return BuildSimpleUrl(request, s.GetResourceName())
+}
+
+func (s ScriptTagWrapper) BuildUpdateUrl(request Request) string {
+	return BuildIdUrl(request, s.GetResourceName(), s.GetId())
+}
+
+type ScriptTags struct {
+	ScriptTags []ScriptTag `json:"script_tags"`
+}
+
+type ScriptTagsWrapper struct {
+	ScriptTags []ScriptTag
+}
+
+func (s *ScriptTagsWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper ScriptTags
+	if err = json.Unmarshal(data, &wrapper); err != nil {
 		return
 	}
 
-	req, err := http.NewRequest("POST", requestUrl, bytes.NewBuffer(requestStr))
+	s.ScriptTags = append(s.ScriptTags, wrapper.ScriptTags...)
+	return
+}
+
+func (s ScriptTagsWrapper) GetResourceName() string {
+	return "script_tags"
+}
+
+type ScriptTagRequestOptions struct {
+	Limit        int      `url:"limit,omitempty"`
+	SinceId      int      `url:"since_id,omitempty"`
+	Src          string   `url:"src,omitempty"`
+	CreatedAtMin string   `url:"created_at_min,omitempty"`
+	CreatedAtMax string   `url:"created_at_max,omitempty"`
+	UpdatedAtMin string   `url:"updated_at_min,omitempty"`
+	UpdatedAtMax string   `url:"updated_at_max,omitempty"`
+	Fields       []string `url:"fields,omitempty,comma"`
+}
+
+func (s ScriptTagRequestOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(s)
 	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", s)
 		return
 	}
 
-	req.WithContext(details.Ctx)
+	queryParams = values.Encode()
+	return
+}
+
+func (r *RestAdminClient) ScriptTagList(context Ctx, options ScriptTagRequestOptions) (results []ScriptTag, next string, err error) {
+	var wrapper = &ScriptTagsWrapper{}
+	next, err = r.List(context, options, wrapper)
+	results = wrapper.ScriptTags
+	return
+}
+
+type ScriptTagIterator struct {
+	*Iterator
+}
+
+/*
+The script tags on the current page.
+*/
+func (i *ScriptTagIterator) ScriptTags() []ScriptTag {
+	if i.Page() == nil {
+		return nil
+	}
+
+	return i.Page().(*ScriptTagsWrapper).ScriptTags
+}
+
+func (r *RestAdminClient) ScriptTagListIterator(context Ctx, options ScriptTagRequestOptions) *ScriptTagIterator {
+	return &ScriptTagIterator{r.NewIterator(context, options, func() Lister { return &ScriptTagsWrapper{} })}
+}
+
+func (r *RestAdminClient) ScriptTagGet(context Ctx, id int) (result *ScriptTag, err error) {
+	wrapper := &ScriptTagWrapper{ScriptTag: &ScriptTag{Id: id}}
+	err = r.Get(context, wrapper)
+	result = wrapper.ScriptTag
 
-	req.Header.Add("X-Shopify-Access-Token", details.AccessToken)
-	req.Header.Set("Content-Type", "application/json")
+	return
+}
 
-	resp, err := c.Http.Do(req)
-	defer resp.Body.Close()
+func (r *RestAdminClient) ScriptTagCount(context Ctx, options ScriptTagRequestOptions) (count int, err error) {
+	return r.Count(context, "script_tags", options)
+}
+
+func (r *RestAdminClient) ScriptTagCreate(context Ctx, request ScriptTag) (result *ScriptTag, err error) {
+	var returnWrapper = new(ScriptTagWrapper)
+	err = r.Create(context, returnWrapper, ScriptTagWrapper{ScriptTag: &request})
+	result = returnWrapper.ScriptTag
+
+	return
+}
+
+func (r *RestAdminClient) ScriptTagUpdate(context Ctx, request ScriptTag) (result *ScriptTag, err error) {
+	var returnWrapper = new(ScriptTagWrapper)
+	err = r.Update(context, returnWrapper, ScriptTagWrapper{ScriptTag: &request})
+	result = returnWrapper.ScriptTag
+
+	return
+}
+
+func (r *RestAdminClient) ScriptTagDelete(context Ctx, id int) (err error) {
+	return r.Delete(context, "script_tags", id)
+}
+
+/*
+Returns the shop's script tag for src, creating it with the onload event when there is none. When
+earlier installs left several tags for src the oldest is returned and the others are left in place,
+use RemoveDuplicateScriptTags to delete them.
+*/
+func (r *RestAdminClient) EnsureScriptTag(context Ctx, src string) (result *ScriptTag, err error) {
+	matches, err := r.scriptTagsForSrc(context, src)
 	if err != nil {
 		return
 	}
 
-	if resp.StatusCode != 201 {
-		c.Logger.Println("The billing request response status code is: ", resp.StatusCode)
-		return result, errors.New("Received non 201 response code from the server")
+	if len(matches) == 0 {
+		return r.ScriptTagCreate(context, ScriptTag{Event: ScriptTagEventOnload, Src: src})
+	}
+
+	result = oldestScriptTag(matches)
+	return
+}
+
+/*
+Deletes every script tag for src except the oldest one, which is returned. The result is nil when
+the shop has no tag for src. Any tag with the same src is deleted, including ones another install
+of the app created on purpose.
+*/
+func (r *RestAdminClient) RemoveDuplicateScriptTags(context Ctx, src string) (result *ScriptTag, err error) {
+	matches, err := r.scriptTagsForSrc(context, src)
+	if err != nil || len(matches) == 0 {
+		return
+	}
+
+	oldest := oldestScriptTag(matches)
+	for _, tag := range matches {
+		if tag.Id == oldest.Id {
+			continue
+		}
+		if err = r.ScriptTagDelete(context, tag.Id); err != nil {
+			err = errors.WithMessagef(err, "unable to delete the duplicate script tag %v", tag.Id)
+			return
+		}
 	}
 
-	buf, _ := ioutil.ReadAll(resp.Body)
-	c.Logger.Println("The response for the recurring billing request is: ", string(buf))
-	wrapper := ScriptTageWrapper{}
-	err = json.Unmarshal(buf, &wrapper)
+	result = oldest
+	return
+}
+
+func (r *RestAdminClient) scriptTagsForSrc(context Ctx, src string) (matches []ScriptTag, err error) {
+	context.AutoPaginate = true
+	context.CursorUrl = ""
+	existing, _, err := r.ScriptTagList(context, ScriptTagRequestOptions{Src: src, Limit: 250})
 	if err != nil {
-		c.Logger.Println("Error unmarshaling the script tag response", err.Error())
+		err = errors.WithMessagef(err, "unable to list the script tags for %v", src)
+		return
 	}
 
-	c.Logger.Println("The result of the unmarshaling: ", wrapper)
-	result = wrapper.ScriptTag
+	for _, tag := range existing {
+		if tag.Src == src {
+			matches = append(matches, tag)
+		}
+	}
 
 	return
 }
+
+func oldestScriptTag(tags []ScriptTag) *ScriptTag {
+	oldest := tags[0]
+	for _, tag := range tags[1:] {
+		if tag.Id < oldest.Id {
+			oldest = tag
+		}
+	}
+
+	return &oldest
+}
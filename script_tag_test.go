@@ -0,0 +1,48 @@
+package shopify
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestEnsureScriptTag(t *testing.T) {
+	var created, deleted int
+	tags := `{"script_tags":[{"id":596726825,"src":"https://example.com/app.js","event":"onload"},{"id":421379493,"src":"https://example.com/app.js","event":"onload"}]}`
+	server, client, requestContext := newTestClient(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/admin/api/2020-10/script_tags.json":
+			_, _ = rw.Write([]byte(tags))
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2020-10/script_tags.json":
+			created++
+			rw.WriteHeader(http.StatusCreated)
+			_, _ = rw.Write([]byte(`{"script_tag":{"id":870402694,"src":"https://example.com/app.js","event":"onload"}}`))
+		case req.Method == "DELETE" && req.URL.Path == "/admin/api/2020-10/script_tags/596726825.json":
+			deleted++
+			_, _ = rw.Write([]byte(`{}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	tag, err := client.EnsureScriptTag(requestContext, "https://example.com/app.js")
+	if err != nil || tag.Id != 421379493 || created != 0 || deleted != 0 {
+		t.Fatalf("unexpected tag %+v %v created %v deleted %v", tag, err, created, deleted)
+	}
+
+	tag, err = client.RemoveDuplicateScriptTags(requestContext, "https://example.com/app.js")
+	if err != nil || tag.Id != 421379493 || deleted != 1 {
+		t.Fatalf("unexpected tag %+v %v deleted %v", tag, err, deleted)
+	}
+
+	tags = `{"script_tags":[]}`
+	tag, err = client.EnsureScriptTag(requestContext, "https://example.com/app.js")
+	if err != nil || tag.Id != 870402694 || created != 1 {
+		t.Errorf("unexpected tag %+v %v created %v", tag, err, created)
+	}
+
+	tag, err = client.RemoveDuplicateScriptTags(requestContext, "https://example.com/app.js")
+	if err != nil || tag != nil || deleted != 1 {
+		t.Errorf("unexpected tag %+v %v deleted %v", tag, err, deleted)
+	}
+}
@@ -0,0 +1,156 @@
+package shopify
+
+import (
+	"encoding/json"
+	"github.com/google/go-querystring/query"
+	"github.com/pkg/errors"
+)
+
+const (
+	TransactionKindAuthorization = "authorization"
+	TransactionKindCapture       = "capture"
+	TransactionKindSale          = "sale"
+	TransactionKindVoid          = "void"
+	TransactionKindRefund        = "refund"
+	// The kind of the transactions RefundCalculate suggests.
+	TransactionKindSuggestedRefund = "suggested_refund"
+)
+
+type Transaction struct {
+	Amount            Money           `json:"amount,omitempty"`
+	Authorization     string          `json:"authorization,omitempty"`
+	CreatedAt         string          `json:"created_at,omitempty"`
+	Currency          string          `json:"currency,omitempty"`
+	DeviceId          int             `json:"device_id,omitempty"`
+	ErrorCode         string          `json:"error_code,omitempty"`
+	Gateway           string          `json:"gateway,omitempty"`
+	Id                int             `json:"id,omitempty"`
+	Kind              string          `json:"kind,omitempty"`
+	LocationId        int             `json:"location_id,omitempty"`
+	MaximumRefundable Money           `json:"maximum_refundable,omitempty"`
+	Message           string          `json:"message,omitempty"`
+	OrderId           int             `json:"order_id,omitempty"`
+	ParentId          int             `json:"parent_id,omitempty"`
+	ProcessedAt       string          `json:"processed_at,omitempty"`
+	Receipt           json.RawMessage `json:"receipt,omitempty"`
+	SourceName        string          `json:"source_name,omitempty"`
+	Status            string          `json:"status,omitempty"`
+	Test              bool            `json:"test,omitempty"`
+	UserId            int             `json:"user_id,omitempty"`
+}
+
+type TransactionWrapper struct {
+	Transaction *Transaction `json:"transaction"`
+}
+
+func (t TransactionWrapper) GetResourceName() string {
+	return orderPath(t.Transaction.OrderId) + "/transactions"
+}
+
+func (t TransactionWrapper) GetId() int {
+	return t.Transaction.Id
+}
+
+func (t TransactionWrapper) BuildGetUrl(request Request) string {
+	return BuildIdUrl(request, t.GetResourceName(), t.GetId())
+}
+
+func (t TransactionWrapper) BuildCreateUrl(request Request) string {
+	return BuildSimpleUrl(request, t.GetResourceName())
+}
+
+type Transactions struct {
+	Transactions []Transaction `json:"transactions"`
+}
+
+type TransactionsWrapper struct {
+	OrderId      int
+	Transactions []Transaction
+}
+
+func (t *TransactionsWrapper) UnmarshalJSON(data []byte) (err error) {
+	var wrapper Transactions
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return
+	}
+
+	t.Transactions = append(t.Transactions, wrapper.Transactions...)
+	return
+}
+
+func (t TransactionsWrapper) GetResourceName() string {
+	return orderPath(t.OrderId) + "/transactions"
+}
+
+type TransactionRequestOptions struct {
+	Fields         []string `url:"fields,omitempty,comma"`
+	InShopCurrency bool     `url:"in_shop_currency,omitempty"`
+	SinceId        int      `url:"since_id,omitempty"`
+}
+
+func (t TransactionRequestOptions) UrlOptionsString() (queryParams string, err error) {
+	values, err := query.Values(t)
+	if err != nil {
+		err = errors.WithMessagef(err, "unable to encode options as query param %v", t)
+		return
+	}
+
+	queryParams = values.Encode()
+	return
+}
+
+/*
+Lists every transaction of the order, the endpoint isn't paginated.
+*/
+func (r *RestAdminClient) TransactionList(context Ctx, orderId int, options TransactionRequestOptions) (results []Transaction, err error) {
+	var wrapper = &TransactionsWrapper{OrderId: orderId}
+	_, err = r.List(context, options, wrapper)
+	results = wrapper.Transactions
+	return
+}
+
+func (r *RestAdminClient) TransactionGet(context Ctx, orderId int, id int) (result *Transaction, err error) {
+	wrapper := &TransactionWrapper{Transaction: &Transaction{OrderId: orderId, Id: id}}
+	err = r.Get(context, wrapper)
+	result = wrapper.Transaction
+
+	return
+}
+
+func (r *RestAdminClient) TransactionCount(context Ctx, orderId int) (count int, err error) {
+	return r.Count(context, orderPath(orderId)+"/transactions", nil)
+}
+
+/*
+Creates a transaction on the order. A capture, void or refund needs the ParentId of the
+authorization or sale it applies to.
+*/
+func (r *RestAdminClient) TransactionCreate(context Ctx, orderId int, request Transaction) (result *Transaction, err error) {
+	request.OrderId = orderId
+	var returnWrapper = new(TransactionWrapper)
+	err = r.Create(context, returnWrapper, TransactionWrapper{Transaction: &request})
+	result = returnWrapper.Transaction
+
+	return
+}
+
+/*
+Captures the amount of an authorization, an empty amount captures all of it.
+*/
+func (r *RestAdminClient) TransactionCapture(context Ctx, orderId int, authorizationId int, amount Money) (result *Transaction, err error) {
+	return r.TransactionCreate(context, orderId, Transaction{Kind: TransactionKindCapture, ParentId: authorizationId, Amount: amount})
+}
+
+/*
+Voids an authorization that hasn't been captured.
+*/
+func (r *RestAdminClient) TransactionVoid(context Ctx, orderId int, authorizationId int) (result *Transaction, err error) {
+	return r.TransactionCreate(context, orderId, Transaction{Kind: TransactionKindVoid, ParentId: authorizationId})
+}
+
+/*
+Refunds the amount of a capture or sale without touching line items or restocking, use RefundCreate for that.
+*/
+func (r *RestAdminClient) TransactionRefund(context Ctx, orderId int, parentId int, amount Money, currency string) (result *Transaction, err error) {
+	return r.TransactionCreate(context, orderId, Transaction{Kind: TransactionKindRefund, ParentId: parentId, Amount: amount, Currency: currency})
+}
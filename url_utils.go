@@ -1,17 +1,175 @@
 package shopify
 
-import "strings"
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
 
 /*
-Used to extract the cursor based url from the response header.
+A single entry of a Link header. Rels holds every relation type of the entry and Params
+holds the remaining parameters keyed by their lower cased name. PageInfo is the page_info
+query parameter of the url, which is the cursor Shopify uses for pagination.
 */
-func ExtractNextCursorUrl(header string) string {
-	headerSplit := strings.Split(header, " ")
-	next := headerSplit[0]
-	if next != "" {
-		return next[1 : len(headerSplit[0])-2]
+type Link struct {
+	Url      string
+	Rels     []string
+	Params   map[string]string
+	PageInfo string
+}
+
+type Links []Link
+
+/*
+Finds the first link with the given relation type, relation types are case insensitive.
+*/
+func (l Links) Find(rel string) (link Link, ok bool) {
+	for _, candidate := range l {
+		for _, candidateRel := range candidate.Rels {
+			if strings.EqualFold(candidateRel, rel) {
+				return candidate, true
+			}
+		}
+	}
+
+	return
+}
+
+/*
+The url of the next page, empty on the last page.
+*/
+func (l Links) Next() string {
+	link, _ := l.Find("next")
+	return link.Url
+}
+
+/*
+The url of the previous page, empty on the first page. Shopify uses "previous" but "prev" is accepted also.
+*/
+func (l Links) Previous() string {
+	link, ok := l.Find("previous")
+	if !ok {
+		link, _ = l.Find("prev")
+	}
+	return link.Url
+}
+
+/*
+Parses a Link header as described by RFC 8288, for example
+<https://shop.myshopify.com/admin/api/2020-10/products.json?page_info=abc>; rel="previous", <...>; rel="next"
+Entries that can't be parsed are skipped rather than failing the whole header.
+*/
+func ParseLinkHeader(header string) (links Links) {
+	rest := header
+	for {
+		start := strings.IndexByte(rest, '<')
+		if start < 0 {
+			return
+		}
+
+		end := strings.IndexByte(rest[start:], '>')
+		if end < 0 {
+			return
+		}
+		end += start
+
+		link := Link{
+			Url:    strings.TrimSpace(rest[start+1 : end]),
+			Params: make(map[string]string),
+		}
+		rest = parseLinkParams(rest[end+1:], &link)
+
+		if rel, ok := link.Params["rel"]; ok {
+			link.Rels = strings.Fields(rel)
+			delete(link.Params, "rel")
+		}
+
+		if parsed, err := url.Parse(link.Url); err == nil {
+			link.PageInfo = parsed.Query().Get("page_info")
+		}
+
+		links = append(links, link)
+	}
+}
+
+/*
+Reads the ;name=value parameters following a link url and returns what is left after the
+comma that ends the entry. Values may be tokens or quoted strings with backslash escapes.
+*/
+func parseLinkParams(rest string, link *Link) string {
+	for {
+		rest = strings.TrimLeft(rest, " \t")
+		if rest == "" {
+			return rest
+		}
+
+		switch rest[0] {
+		case ',':
+			return rest[1:]
+		case ';':
+			rest = rest[1:]
+		default:
+			return rest
+		}
+
+		rest = strings.TrimLeft(rest, " \t")
+		nameEnd := strings.IndexAny(rest, "=;,")
+		if nameEnd < 0 {
+			nameEnd = len(rest)
+		}
+		name := strings.ToLower(strings.TrimSpace(rest[:nameEnd]))
+		rest = rest[nameEnd:]
+
+		var value string
+		if strings.HasPrefix(rest, "=") {
+			value, rest = parseLinkParamValue(strings.TrimLeft(rest[1:], " \t"))
+		}
+
+		if _, exists := link.Params[name]; name != "" && !exists {
+			link.Params[name] = value
+		}
+	}
+}
+
+func parseLinkParamValue(rest string) (value string, remaining string) {
+	if !strings.HasPrefix(rest, "\"") {
+		end := strings.IndexAny(rest, ";,")
+		if end < 0 {
+			end = len(rest)
+		}
+		return strings.TrimSpace(rest[:end]), rest[end:]
 	}
-	return next
+
+	builder := strings.Builder{}
+	for i := 1; i < len(rest); i++ {
+		switch rest[i] {
+		case '\\':
+			if i+1 < len(rest) {
+				i++
+				builder.WriteByte(rest[i])
+			}
+		case '"':
+			return builder.String(), rest[i+1:]
+		default:
+			builder.WriteByte(rest[i])
+		}
+	}
+
+	return builder.String(), ""
+}
+
+/*
+Used to extract the cursor based url for the next page from the response header.
+*/
+func ExtractNextCursorUrl(header string) string {
+	return ParseLinkHeader(header).Next()
+}
+
+/*
+Used to extract the cursor based url for the previous page from the response header.
+*/
+func ExtractPreviousCursorUrl(header string) string {
+	return ParseLinkHeader(header).Previous()
 }
 
 /*
@@ -48,7 +206,8 @@ func BuildIdUrl(request Request, resource string, id int) (url string) {
 	pathBuilder.WriteString(BuildBaseUrl(request))
 	pathBuilder.WriteString("/")
 	pathBuilder.WriteString(resource)
-	pathBuilder.WriteString(string(id))
+	pathBuilder.WriteString("/")
+	pathBuilder.WriteString(strconv.Itoa(id))
 	pathBuilder.WriteString(".json")
 	url = pathBuilder.String()
 	return
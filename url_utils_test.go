@@ -9,3 +9,46 @@ func TestParseLinkHeader(t *testing.T) {
 		t.Errorf("the extracted header value is not what was expected %s", url)
 	}
 }
+
+func TestParseLinkHeaderWithPreviousAndNext(t *testing.T) {
+	header := `<https://test.myshopify.com/admin/api/2019-07/products.json?limit=3&page_info=abc>; rel="previous", ` +
+		`<https://test.myshopify.com/admin/api/2019-07/products.json?limit=3&page_info=def>; rel="next"`
+	links := ParseLinkHeader(header)
+	if len(links) != 2 {
+		t.Fatalf("expected two links, got %v", links)
+	}
+
+	if links.Previous() != "https://test.myshopify.com/admin/api/2019-07/products.json?limit=3&page_info=abc" {
+		t.Errorf("unexpected previous link %s", links.Previous())
+	}
+	if ExtractNextCursorUrl(header) != "https://test.myshopify.com/admin/api/2019-07/products.json?limit=3&page_info=def" {
+		t.Errorf("unexpected next link %s", ExtractNextCursorUrl(header))
+	}
+
+	next, _ := links.Find("next")
+	if next.PageInfo != "def" || links[0].PageInfo != "abc" {
+		t.Errorf("unexpected page info values %s %s", links[0].PageInfo, next.PageInfo)
+	}
+}
+
+func TestParseLinkHeaderParams(t *testing.T) {
+	header := `<https://example.com/a,b>;rel="next last";title="a \"quoted\", title", <https://example.com/c>; REL=prev`
+	links := ParseLinkHeader(header)
+	if len(links) != 2 {
+		t.Fatalf("expected two links, got %v", links)
+	}
+
+	if links[0].Url != "https://example.com/a,b" || links[0].Params["title"] != `a "quoted", title` {
+		t.Errorf("unexpected first link %+v", links[0])
+	}
+	if last, ok := links.Find("last"); !ok || last.Url != "https://example.com/a,b" {
+		t.Error("expected every relation of a link to be found")
+	}
+	if links.Previous() != "https://example.com/c" {
+		t.Errorf("unexpected previous link %s", links.Previous())
+	}
+
+	if ParseLinkHeader("") != nil || ExtractPreviousCursorUrl("") != "" {
+		t.Error("expected an empty header to have no links")
+	}
+}
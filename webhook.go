@@ -19,13 +19,13 @@ const (
 
 type Webhook struct {
 	Address             string   `json:"address"`
-	Created_at          string   `json:"created_at, omitempty"`
-	Fields              []string `json:"fields omitempty"`
+	Created_at          string   `json:"created_at,omitempty"`
+	Fields              []string `json:"fields,omitempty"`
 	Format              string   `json:"format"`
-	Id                  int      `json:"id, omitempty"`
-	MetafieldNamespaces []string `json:"metafield_namespaces, omitempty"`
+	Id                  int      `json:"id,omitempty"`
+	MetafieldNamespaces []string `json:"metafield_namespaces,omitempty"`
 	Topic               string   `json:"topic"`
-	UpdatedAt           string   `json:"updated_at, omitempty"`
+	UpdatedAt           string   `json:"updated_at,omitempty"`
 }
 
 type WebhookWrapper struct {
@@ -40,6 +40,33 @@ func (w WebhookWrapper) BuildCreateUrl(request Request) string {
 	return BuildSimpleUrl(request, w.GetResourceName())
 }
 
+func (w WebhookWrapper) BuildUpdateUrl(request Request) string {
+	return BuildIdUrl(request, w.GetResourceName(), w.Webhook.Id)
+}
+
+/*
+Used for updates so that empty fields and metafield namespaces are sent, which clears them.
+*/
+type webhookUpdate struct {
+	Id                  int      `json:"id"`
+	Address             string   `json:"address"`
+	Fields              []string `json:"fields"`
+	Format              string   `json:"format"`
+	MetafieldNamespaces []string `json:"metafield_namespaces"`
+}
+
+type webhookUpdateWrapper struct {
+	Webhook webhookUpdate `json:"webhook"`
+}
+
+func (w webhookUpdateWrapper) GetResourceName() string {
+	return "webhooks"
+}
+
+func (w webhookUpdateWrapper) BuildUpdateUrl(request Request) string {
+	return BuildIdUrl(request, w.GetResourceName(), w.Webhook.Id)
+}
+
 type Webhooks struct {
 	Webhooks []Webhook `json:"webhooks"`
 }
@@ -95,6 +122,31 @@ func (r *RestAdminClient) WebhookCreate(context Ctx, request Webhook) (result *W
 	return
 }
 
+/*
+Updates the address, format, fields and metafield namespaces of the webhook, the topic can't be changed.
+*/
+func (r *RestAdminClient) WebhookUpdate(context Ctx, request Webhook) (result *Webhook, err error) {
+	var returnWrapper = new(WebhookWrapper)
+	requestWrapper := webhookUpdateWrapper{Webhook: webhookUpdate{
+		Id:                  request.Id,
+		Address:             request.Address,
+		Fields:              nonNilStrings(request.Fields),
+		Format:              request.Format,
+		MetafieldNamespaces: nonNilStrings(request.MetafieldNamespaces),
+	}}
+	err = r.Update(context, returnWrapper, requestWrapper)
+	result = returnWrapper.Webhook
+
+	return
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
 func (r *RestAdminClient) WebhookDelete(context Ctx, id int) (err error) {
 	err = r.Delete(context, "webhooks", id)
 	if err != nil {
@@ -110,3 +162,22 @@ func (r *RestAdminClient) WebhookList(context Ctx, options WebHookRequestOptions
 	results = wrapper.Webhooks
 	return
 }
+
+type WebhookIterator struct {
+	*Iterator
+}
+
+/*
+The webhooks on the current page.
+*/
+func (i *WebhookIterator) Webhooks() []Webhook {
+	if i.Page() == nil {
+		return nil
+	}
+
+	return i.Page().(*WebhooksWrapper).Webhooks
+}
+
+func (r *RestAdminClient) WebhookListIterator(context Ctx, options WebHookRequestOptions) *WebhookIterator {
+	return &WebhookIterator{r.NewIterator(context, options, func() Lister { return &WebhooksWrapper{} })}
+}
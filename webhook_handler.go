@@ -0,0 +1,188 @@
+package shopify
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	XShopifyTopic      = "X-Shopify-Topic"
+	XShopifyWebhookId  = "X-Shopify-Webhook-Id"
+	XShopifyApiVersion = "X-Shopify-Api-Version"
+
+	ShopUpdate = "shop/update"
+
+	maxWebhookBodyBytes = 10 << 20
+)
+
+/*
+The payload types webhooks are decoded into, keyed by the full topic or by the resource part of
+the topic (products for products/create). Topics without an entry are decoded into a map.
+*/
+var webhookPayloads = map[string]func() interface{}{
+	"products":     func() interface{} { return &Product{} },
+	"orders":       func() interface{} { return &Order{} },
+	"customers":    func() interface{} { return &Customer{} },
+	AppUninstalled: func() interface{} { return &Shop{} },
+	ShopUpdate:     func() interface{} { return &Shop{} },
+}
+
+/*
+A single verified webhook delivery. Payload holds the decoded body, for example a *Product for
+the products/* topics and a *Shop for app/uninstalled.
+*/
+type WebhookDelivery struct {
+	Topic      string
+	ShopDomain string
+	WebhookId  string
+	ApiVersion string
+	Body       []byte
+	Payload    interface{}
+}
+
+type WebhookHandlerFunc func(delivery WebhookDelivery)
+
+/*
+WebhookHandler is an http.Handler that receives webhook deliveries. It verifies the
+X-Shopify-Hmac-Sha256 header against the app secret, decodes the body for the topic and hands
+the delivery to the handler registered for the topic. Bad signatures get a 401, accepted
+deliveries get a 200 straight away and the handler runs in its own goroutine so Shopify
+doesn't time out waiting on it.
+
+A signed body that doesn't decode into the topic's type is still acknowledged with a 200, Shopify
+would otherwise retry it and eventually remove the webhook. The error is logged and passed to
+DecodeError when it is set.
+*/
+type WebhookHandler struct {
+	Secret string
+	Logger *log.Logger
+	// Optional, called in its own goroutine with a verified delivery whose body couldn't be decoded.
+	DecodeError func(delivery WebhookDelivery, err error)
+
+	mu       sync.RWMutex
+	handlers map[string]WebhookHandlerFunc
+}
+
+func NewWebhookHandler(secret string, logger *log.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		Secret:   secret,
+		Logger:   logger,
+		handlers: make(map[string]WebhookHandlerFunc),
+	}
+}
+
+/*
+Registers the handler for a topic, replacing any earlier handler for it.
+*/
+func (h *WebhookHandler) Handle(topic string, handler WebhookHandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.handlers == nil {
+		h.handlers = make(map[string]WebhookHandlerFunc)
+	}
+	h.handlers[topic] = handler
+}
+
+func (h *WebhookHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(rw, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(rw, req.Body, maxWebhookBodyBytes))
+	if err != nil {
+		http.Error(rw, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	if !VerifyWebhookHmac(body, req.Header.Get(XShopifyHmacSha256), h.Secret) {
+		http.Error(rw, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	delivery := WebhookDelivery{
+		Topic:      req.Header.Get(XShopifyTopic),
+		ShopDomain: req.Header.Get(XShopifyShopDomain),
+		WebhookId:  req.Header.Get(XShopifyWebhookId),
+		ApiVersion: req.Header.Get(XShopifyApiVersion),
+		Body:       body,
+	}
+
+	h.mu.RLock()
+	handler, ok := h.handlers[delivery.Topic]
+	h.mu.RUnlock()
+	if !ok {
+		// nothing is interested in the topic, acknowledge it so Shopify doesn't keep retrying
+		rw.WriteHeader(http.StatusOK)
+		return
+	}
+
+	delivery.Payload, err = decodeWebhookPayload(delivery.Topic, body)
+	if err != nil {
+		h.logf("unable to decode the %v webhook from %v: %v", delivery.Topic, delivery.ShopDomain, err)
+		rw.WriteHeader(http.StatusOK)
+		if h.DecodeError != nil {
+			go h.dispatch(func(delivery WebhookDelivery) { h.DecodeError(delivery, err) }, delivery)
+		}
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	go h.dispatch(handler, delivery)
+}
+
+func (h *WebhookHandler) dispatch(handler WebhookHandlerFunc, delivery WebhookDelivery) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			h.logf("the %v webhook handler panicked for %v: %v", delivery.Topic, delivery.ShopDomain, recovered)
+		}
+	}()
+
+	handler(delivery)
+}
+
+func (h *WebhookHandler) logf(format string, args ...interface{}) {
+	if h.Logger != nil {
+		h.Logger.Printf(format, args...)
+	}
+}
+
+func decodeWebhookPayload(topic string, body []byte) (payload interface{}, err error) {
+	newPayload, ok := webhookPayloads[topic]
+	if !ok {
+		newPayload, ok = webhookPayloads[strings.SplitN(topic, "/", 2)[0]]
+	}
+
+	if !ok {
+		var generic map[string]interface{}
+		err = json.Unmarshal(body, &generic)
+		payload = generic
+		return
+	}
+
+	payload = newPayload()
+	err = json.Unmarshal(body, payload)
+	return
+}
+
+/*
+Checks the base64 encoded X-Shopify-Hmac-Sha256 header against the body in constant time.
+*/
+func VerifyWebhookHmac(body []byte, signature string, secret string) bool {
+	expected, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || len(expected) == 0 {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(expected, mac.Sum(nil))
+}
@@ -0,0 +1,114 @@
+package shopify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func signWebhook(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func newWebhookRequest(topic string, body []byte, signature string) *http.Request {
+	req := httptest.NewRequest("POST", "/webhooks", bytes.NewReader(body))
+	req.Header.Set(XShopifyTopic, topic)
+	req.Header.Set(XShopifyShopDomain, "test.myshopify.com")
+	req.Header.Set(XShopifyWebhookId, "b54557e4-bdd9-4b37-8a5f-bf7d70bcd043")
+	req.Header.Set(XShopifyHmacSha256, signature)
+	return req
+}
+
+func TestWebhookHandlerDispatchesTypedPayloads(t *testing.T) {
+	handler := NewWebhookHandler("secret", log.New(os.Stdout, "", log.Ldate|log.Ltime|log.Lshortfile))
+
+	products := make(chan WebhookDelivery, 1)
+	handler.Handle(ProductUpdate, func(delivery WebhookDelivery) {
+		products <- delivery
+	})
+	uninstalls := make(chan WebhookDelivery, 1)
+	handler.Handle(AppUninstalled, func(delivery WebhookDelivery) {
+		uninstalls <- delivery
+	})
+
+	body := []byte(`{"id":788032119674292922,"title":"Example T-Shirt"}`)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newWebhookRequest(ProductUpdate, body, signWebhook(body, "secret")))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected the delivery to be accepted, got %v", recorder.Code)
+	}
+
+	select {
+	case delivery := <-products:
+		product, ok := delivery.Payload.(*Product)
+		if !ok || product.Title != "Example T-Shirt" || delivery.ShopDomain != "test.myshopify.com" || delivery.WebhookId == "" {
+			t.Errorf("unexpected delivery %+v", delivery)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the product handler was not called")
+	}
+
+	body = []byte(`{"id":548380009,"myshopify_domain":"test.myshopify.com"}`)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newWebhookRequest(AppUninstalled, body, signWebhook(body, "secret")))
+	select {
+	case delivery := <-uninstalls:
+		if shop, ok := delivery.Payload.(*Shop); !ok || shop.MyshopifyDomain != "test.myshopify.com" {
+			t.Errorf("unexpected delivery %+v", delivery)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the uninstall handler was not called")
+	}
+}
+
+func TestWebhookHandlerRejectsBadSignatures(t *testing.T) {
+	handler := NewWebhookHandler("secret", nil)
+	handler.Handle(ProductCreate, func(delivery WebhookDelivery) {
+		t.Error("the handler should not be called for a bad signature")
+	})
+
+	body := []byte(`{"id":1}`)
+	for _, signature := range []string{"", "not base64!", signWebhook(body, "othersecret")} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newWebhookRequest(ProductCreate, body, signature))
+		if recorder.Code != http.StatusUnauthorized {
+			t.Errorf("expected a 401 for signature %q, got %v", signature, recorder.Code)
+		}
+	}
+}
+
+func TestWebhookHandlerAcknowledgesUndecodablePayloads(t *testing.T) {
+	handler := NewWebhookHandler("secret", log.New(os.Stdout, "", log.Ldate|log.Ltime|log.Lshortfile))
+	handler.Handle(ProductUpdate, func(delivery WebhookDelivery) {
+		t.Error("the handler should not be called for an undecodable payload")
+	})
+	decodeErrors := make(chan error, 1)
+	handler.DecodeError = func(delivery WebhookDelivery, err error) {
+		decodeErrors <- err
+	}
+
+	body := []byte(`{"id":"not a number"}`)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newWebhookRequest(ProductUpdate, body, signWebhook(body, "secret")))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected the delivery to be acknowledged, got %v", recorder.Code)
+	}
+
+	select {
+	case err := <-decodeErrors:
+		if err == nil {
+			t.Error("expected the decode error to be reported")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the decode error callback was not called")
+	}
+}
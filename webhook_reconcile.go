@@ -0,0 +1,171 @@
+package shopify
+
+import (
+	"github.com/pkg/errors"
+	"sort"
+	"strings"
+)
+
+const defaultWebhookFormat = "json"
+
+/*
+A webhook subscription the app wants to have. Subscriptions are identified by topic and address,
+Format defaults to json.
+*/
+type WebhookSubscription struct {
+	Topic               string
+	Address             string
+	Format              string
+	Fields              []string
+	MetafieldNamespaces []string
+}
+
+/*
+The changes made by WebhookReconcile, or the changes it would make when DryRun is set.
+*/
+type WebhookReconcileReport struct {
+	DryRun    bool
+	Created   []Webhook
+	Updated   []Webhook
+	Deleted   []Webhook
+	Unchanged []Webhook
+}
+
+/*
+Converges the shop's webhooks to the desired subscriptions. Existing webhooks with the same topic and
+address are updated when their format, fields or metafield namespaces differ, missing subscriptions
+are created and every other webhook is deleted. Creates happen before deletes so a moved address
+doesn't miss deliveries.
+
+With dryRun set no changes are sent and the report lists what would have been done. When a change
+fails the report holds the changes made up to that point.
+*/
+func (r *RestAdminClient) WebhookReconcile(context Ctx, desired []WebhookSubscription, dryRun bool) (report WebhookReconcileReport, err error) {
+	report.DryRun = dryRun
+
+	context.AutoPaginate = true
+	context.CursorUrl = ""
+	existing, _, err := r.WebhookList(context, WebHookRequestOptions{Limit: 250})
+	if err != nil {
+		err = errors.WithMessage(err, "unable to list the existing webhooks")
+		return
+	}
+
+	wanted := make(map[string]Webhook, len(desired))
+	var order []string
+	for _, subscription := range desired {
+		key := webhookKey(subscription.Topic, subscription.Address)
+		if _, ok := wanted[key]; !ok {
+			order = append(order, key)
+		}
+		wanted[key] = subscription.webhook()
+	}
+
+	var toCreate, toUpdate, toDelete []Webhook
+	matched := make(map[string]bool, len(existing))
+	for _, webhook := range existing {
+		key := webhookKey(webhook.Topic, webhook.Address)
+		target, ok := wanted[key]
+		if !ok || matched[key] {
+			toDelete = append(toDelete, webhook)
+			continue
+		}
+
+		matched[key] = true
+		if webhookMatches(webhook, target) {
+			report.Unchanged = append(report.Unchanged, webhook)
+			continue
+		}
+
+		target.Id = webhook.Id
+		toUpdate = append(toUpdate, target)
+	}
+
+	for _, key := range order {
+		if !matched[key] {
+			toCreate = append(toCreate, wanted[key])
+		}
+	}
+
+	if dryRun {
+		report.Created = toCreate
+		report.Updated = toUpdate
+		report.Deleted = toDelete
+		return
+	}
+
+	for _, webhook := range toCreate {
+		created, createErr := r.WebhookCreate(context, webhook)
+		if createErr != nil {
+			err = errors.WithMessagef(createErr, "unable to create the %v webhook for %v", webhook.Topic, webhook.Address)
+			return
+		}
+		if created != nil {
+			report.Created = append(report.Created, *created)
+		}
+	}
+
+	for _, webhook := range toUpdate {
+		updated, updateErr := r.WebhookUpdate(context, webhook)
+		if updateErr != nil {
+			err = errors.WithMessagef(updateErr, "unable to update webhook %v", webhook.Id)
+			return
+		}
+		if updated != nil {
+			report.Updated = append(report.Updated, *updated)
+		}
+	}
+
+	for _, webhook := range toDelete {
+		err = r.WebhookDelete(context, webhook.Id)
+		if err != nil {
+			return
+		}
+		report.Deleted = append(report.Deleted, webhook)
+	}
+
+	return
+}
+
+func (s WebhookSubscription) webhook() Webhook {
+	format := s.Format
+	if format == "" {
+		format = defaultWebhookFormat
+	}
+
+	return Webhook{
+		Address:             s.Address,
+		Fields:              s.Fields,
+		Format:              format,
+		MetafieldNamespaces: s.MetafieldNamespaces,
+		Topic:               s.Topic,
+	}
+}
+
+func webhookKey(topic string, address string) string {
+	return topic + " " + address
+}
+
+func webhookMatches(existing Webhook, desired Webhook) bool {
+	return strings.EqualFold(existing.Format, desired.Format) &&
+		sameStringSet(existing.Fields, desired.Fields) &&
+		sameStringSet(existing.MetafieldNamespaces, desired.MetafieldNamespaces)
+}
+
+func sameStringSet(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	sortedA := append([]string(nil), a...)
+	sortedB := append([]string(nil), b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,114 @@
+package shopify
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWebhookReconcile(t *testing.T) {
+	var mu sync.Mutex
+	nextId := 100
+	webhooks := map[int]Webhook{
+		1: {Id: 1, Topic: ProductCreate, Address: "https://app.example.com/products", Format: "json"},
+		2: {Id: 2, Topic: ProductUpdate, Address: "https://app.example.com/products", Format: "json", Fields: []string{"id"}},
+		3: {Id: 3, Topic: ProductDelete, Address: "https://old.example.com/products", Format: "json"},
+	}
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/admin/api/2019-07/webhooks.json":
+			list := Webhooks{Webhooks: []Webhook{}}
+			for id := 1; id < nextId+1; id++ {
+				if webhook, ok := webhooks[id]; ok {
+					list.Webhooks = append(list.Webhooks, webhook)
+				}
+			}
+			_ = json.NewEncoder(rw).Encode(list)
+		case req.Method == "POST" && req.URL.Path == "/admin/api/2019-07/webhooks.json":
+			var wrapper WebhookWrapper
+			_ = json.NewDecoder(req.Body).Decode(&wrapper)
+			nextId++
+			wrapper.Webhook.Id = nextId
+			webhooks[nextId] = *wrapper.Webhook
+			rw.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(rw).Encode(wrapper)
+		case req.Method == "PUT" || req.Method == "DELETE":
+			id, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/admin/api/2019-07/webhooks/"), ".json"))
+			if req.Method == "DELETE" {
+				delete(webhooks, id)
+				_, _ = rw.Write([]byte(`{}`))
+				return
+			}
+
+			var wrapper WebhookWrapper
+			_ = json.NewDecoder(req.Body).Decode(&wrapper)
+			webhook := webhooks[id]
+			webhook.Fields = wrapper.Webhook.Fields
+			webhook.Format = wrapper.Webhook.Format
+			webhooks[id] = webhook
+			_ = json.NewEncoder(rw).Encode(WebhookWrapper{Webhook: &webhook})
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := RestAdminClient{
+		Http:    server.Client(),
+		Logger:  log.New(os.Stdout, "", log.Ldate|log.Ltime|log.Lshortfile),
+		Version: VERSION_2019_07,
+	}
+
+	serverUrl, _ := url.Parse(server.URL)
+	requestContext := Ctx{
+		AccessToken: "thisisatoken",
+		ShopName:    serverUrl.Host,
+		Ctx:         context.Background(),
+	}
+
+	desired := []WebhookSubscription{
+		{Topic: ProductCreate, Address: "https://app.example.com/products"},
+		{Topic: ProductUpdate, Address: "https://app.example.com/products"},
+		{Topic: ProductDelete, Address: "https://app.example.com/products"},
+		{Topic: AppUninstalled, Address: "https://app.example.com/uninstalled"},
+	}
+
+	report, err := client.WebhookReconcile(requestContext, desired, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Created) != 2 || len(report.Updated) != 1 || len(report.Deleted) != 1 || len(report.Unchanged) != 1 {
+		t.Errorf("unexpected dry run report %+v", report)
+	}
+	if len(webhooks) != 3 {
+		t.Errorf("the dry run should not change anything, have %v webhooks", len(webhooks))
+	}
+
+	report, err = client.WebhookReconcile(requestContext, desired, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Created) != 2 || len(report.Updated) != 1 || report.Updated[0].Id != 2 || len(report.Deleted) != 1 || report.Deleted[0].Id != 3 {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	report, err = client.WebhookReconcile(requestContext, desired, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Created) != 0 || len(report.Updated) != 0 || len(report.Deleted) != 0 || len(report.Unchanged) != 4 {
+		t.Errorf("expected the second run to be a no-op %+v", report)
+	}
+}